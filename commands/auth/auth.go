@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lighttiger2505/lab/internal/config"
+	"github.com/mitchellh/cli"
+)
+
+const (
+	ExitCodeOK    int = iota //0
+	ExitCodeError int = iota //1
+)
+
+type HostOption struct {
+	Host string `long:"host" value-name:"<host>" description:"The GitLab host to authenticate with. e.g. gitlab.com"`
+}
+
+// getHost returns the host given by the option,
+// or the only profile or the default profile of the config.
+func (o *HostOption) getHost(cfg *config.Config) (string, error) {
+	if o.Host != "" {
+		return normalizeHost(o.Host), nil
+	}
+	if cfg.DefaultProfile != "" {
+		return cfg.DefaultProfile, nil
+	}
+	if len(cfg.Profiles) == 1 {
+		for domain := range cfg.Profiles {
+			return domain, nil
+		}
+	}
+	return "", fmt.Errorf("Please specify the host with --host")
+}
+
+func normalizeHost(host string) string {
+	host = strings.TrimPrefix(host, "https://")
+	return strings.TrimSuffix(host, "/")
+}
+
+func scopesString(scopes []string) string {
+	if len(scopes) == 0 {
+		return "unknown"
+	}
+	return strings.Join(scopes, ", ")
+}
+
+// AuthCommand is the parent of the auth subcommands.
+type AuthCommand struct{}
+
+func (c *AuthCommand) Synopsis() string {
+	return "Login, logout and show the authentication status"
+}
+
+func (c *AuthCommand) Help() string {
+	return `auth - Login, logout and show the authentication status
+
+Synopsis:
+  lab auth login [--host <host>] [--token <token>] [--keyring] [--default]
+  lab auth login --web --client-id <application id> [--port <port>] [--host <host>] [--keyring] [--default]
+  lab auth status
+  lab auth logout [--host <host>]`
+}
+
+func (c *AuthCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lighttiger2505/lab/internal/api"
+	"github.com/lighttiger2505/lab/internal/config"
+	"github.com/lighttiger2505/lab/internal/ui"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+func TestStatusCommand_Run(t *testing.T) {
+	mockUI := ui.NewMockUi()
+	c := &StatusCommand{
+		UI: mockUI,
+		Config: &config.Config{
+			Profiles: map[string]config.Profile{
+				"gitlab.com":         {Token: "validtoken"},
+				"gitlab.example.com": {},
+			},
+			DefaultProfile: "gitlab.com",
+		},
+		ClientFactory: &api.MockAPIClientFactory{
+			MockGetUserClient: func() api.User {
+				return &api.MockUserClient{
+					MockCurrentUser: func() (*gitlab.User, error) {
+						return &gitlab.User{Username: "lighttiger2505"}, nil
+					},
+					MockCurrentTokenScopes: func() ([]string, error) {
+						return []string{"api", "read_user"}, nil
+					},
+				}
+			},
+		},
+	}
+
+	if code := c.Run([]string{}); code != ExitCodeError {
+		t.Errorf("bad exit code, got %d, want %d", code, ExitCodeError)
+	}
+
+	got := mockUI.Writer.String()
+	for _, want := range []string{
+		"gitlab.com (default)  @lighttiger2505  valid token (scopes: api, read_user)",
+		"gitlab.example.com    -                not logged in",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output does not contain %q\n%s", want, got)
+		}
+	}
+}
+
+func TestHostOption_getHost(t *testing.T) {
+	tests := []struct {
+		name    string
+		opt     *HostOption
+		cfg     *config.Config
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "option",
+			opt:  &HostOption{Host: "https://gitlab.example.com/"},
+			cfg:  &config.Config{},
+			want: "gitlab.example.com",
+		},
+		{
+			name: "default profile",
+			opt:  &HostOption{},
+			cfg: &config.Config{
+				Profiles:       map[string]config.Profile{"gitlab.com": {}, "gitlab.example.com": {}},
+				DefaultProfile: "gitlab.com",
+			},
+			want: "gitlab.com",
+		},
+		{
+			name: "only profile",
+			opt:  &HostOption{},
+			cfg: &config.Config{
+				Profiles: map[string]config.Profile{"gitlab.example.com": {}},
+			},
+			want: "gitlab.example.com",
+		},
+		{
+			name: "ambiguous",
+			opt:  &HostOption{},
+			cfg: &config.Config{
+				Profiles: map[string]config.Profile{"gitlab.com": {}, "gitlab.example.com": {}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.opt.getHost(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getHost() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("getHost() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package auth
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/lighttiger2505/lab/commands/internal"
+	"github.com/lighttiger2505/lab/internal/api"
+	"github.com/lighttiger2505/lab/internal/browse"
+	"github.com/lighttiger2505/lab/internal/config"
+	"github.com/lighttiger2505/lab/internal/oauth"
+	"github.com/lighttiger2505/lab/internal/ui"
+)
+
+type LoginOption struct {
+	HostOption *HostOption `group:"Host Options"`
+	Token      string      `long:"token" value-name:"<token>" description:"The personal access token. Ask for it when not specified"`
+	Web        bool        `short:"w" long:"web" description:"Login through the browser with OAuth instead of a personal access token"`
+	ClientID   string      `long:"client-id" value-name:"<application id>" description:"The application id of the OAuth application. The redirect URI of the application must be http://127.0.0.1:<port>/callback"`
+	Port       int         `long:"port" value-name:"<port>" default:"7878" default-mask:"7878" description:"The port of the loopback redirect URI"`
+	Keyring    bool        `long:"keyring" description:"Store the token in the OS keyring instead of the config file"`
+	Default    bool        `long:"default" description:"Use the host as the default profile"`
+}
+
+func newLoginOptionParser(opt *LoginOption) *flags.Parser {
+	opt.HostOption = &HostOption{}
+	parser := flags.NewParser(opt, flags.HelpFlag|flags.PassDoubleDash)
+	parser.Usage = `auth login - Authenticate with a GitLab host
+
+Synopsis:
+  # Login with a personal access token
+  lab auth login [--host <host>] [--token <token>] [--keyring] [--default]
+
+  # Login through the browser with OAuth
+  lab auth login --web --client-id <application id> [--port <port>] [--host <host>] [--keyring] [--default]`
+	return parser
+}
+
+type LoginCommand struct {
+	UI            ui.UI
+	Config        *config.Config
+	ClientFactory api.APIClientFactory
+	Opener        browse.URLOpener
+	// BaseURL overrides the URL of the authorization server, for testing
+	BaseURL string
+}
+
+func (c *LoginCommand) Synopsis() string {
+	return "Authenticate with a GitLab host"
+}
+
+func (c *LoginCommand) Help() string {
+	buf := &bytes.Buffer{}
+	var opt LoginOption
+	parser := newLoginOptionParser(&opt)
+	parser.WriteHelp(buf)
+	return buf.String()
+}
+
+func (c *LoginCommand) Run(args []string) int {
+	var opt LoginOption
+	parser := newLoginOptionParser(&opt)
+	if _, err := parser.ParseArgs(args); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+
+	if err := c.login(&opt); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+	return ExitCodeOK
+}
+
+func (c *LoginCommand) login(opt *LoginOption) error {
+	domain := normalizeHost(opt.HostOption.Host)
+	if domain == "" {
+		domain = "gitlab.com"
+	}
+
+	profile := config.Profile{}
+	if c.Config.HasDomain(domain) {
+		p, err := c.Config.GetProfile(domain)
+		if err != nil {
+			return err
+		}
+		profile = *p
+	}
+	if profile.TokenCommand != "" {
+		return fmt.Errorf("The profile [%s] uses token_command, the token can't be saved", domain)
+	}
+
+	var token, refreshToken, tokenType string
+	var err error
+	if opt.Web {
+		token, refreshToken, err = c.webLogin(opt, domain, &profile)
+		tokenType = api.OAuthToken
+	} else {
+		token, err = c.askToken(opt, domain)
+		tokenType = api.PrivateToken
+		profile.OAuthClientID = ""
+		profile.RefreshToken = ""
+	}
+	if err != nil {
+		return err
+	}
+
+	user, scopes, err := internal.ValidateToken(c.ClientFactory, domain, token, tokenType)
+	if err != nil {
+		return fmt.Errorf("Invalid token for [%s], %s", domain, err)
+	}
+	if opt.Web {
+		c.UI.Message(fmt.Sprintf("Logged in to %s as @%s (OAuth)", domain, user.Username))
+	} else {
+		c.UI.Message(fmt.Sprintf("Logged in to %s as @%s (scopes: %s)", domain, user.Username, scopesString(scopes)))
+	}
+
+	if opt.Keyring {
+		profile.TokenStore = config.TokenStoreKeyring
+	}
+	c.Config.SetProfile(domain, profile)
+	if err := c.Config.SetToken(domain, token); err != nil {
+		return err
+	}
+	if refreshToken != "" {
+		if err := c.Config.SetRefreshToken(domain, refreshToken); err != nil {
+			return err
+		}
+	}
+	if opt.Default || c.Config.DefaultProfile == "" {
+		c.Config.DefaultProfile = domain
+	}
+	if err := c.Config.Save(); err != nil {
+		return err
+	}
+	c.UI.Message("Saved profile.")
+	return nil
+}
+
+func (c *LoginCommand) askToken(opt *LoginOption, domain string) (string, error) {
+	token := opt.Token
+	if token == "" {
+		c.UI.Message(fmt.Sprintf("Create a personal access token with the api scope at https://%s/-/profile/personal_access_tokens", domain))
+		t, err := c.UI.Ask("Please enter GitLab private token:")
+		if err != nil {
+			return "", fmt.Errorf("cannot read private token, %s", err)
+		}
+		token = strings.TrimSpace(t)
+	}
+	if token == "" {
+		return "", fmt.Errorf("Empty private token")
+	}
+	return token, nil
+}
+
+// webLogin runs the OAuth authorization code flow, and returns the access and refresh token.
+func (c *LoginCommand) webLogin(opt *LoginOption, domain string, profile *config.Profile) (string, string, error) {
+	clientID := opt.ClientID
+	if clientID == "" {
+		clientID = profile.OAuthClientID
+	}
+	if clientID == "" {
+		return "", "", fmt.Errorf("Please specify the application id of the OAuth application with --client-id")
+	}
+	profile.OAuthClientID = clientID
+
+	baseURL := c.BaseURL
+	if baseURL == "" {
+		baseURL = "https://" + domain
+	}
+	client := &oauth.Client{
+		BaseURL:  baseURL,
+		ClientID: clientID,
+		Port:     opt.Port,
+	}
+	c.UI.Message("Opening the browser to authorize lab...")
+	token, err := client.Login(c.Opener)
+	if err != nil {
+		return "", "", err
+	}
+	return token.AccessToken, token.RefreshToken, nil
+}
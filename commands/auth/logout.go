@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"bytes"
+	"fmt"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/lighttiger2505/lab/internal/config"
+	"github.com/lighttiger2505/lab/internal/ui"
+)
+
+type LogoutOption struct {
+	HostOption *HostOption `group:"Host Options"`
+}
+
+func newLogoutOptionParser(opt *LogoutOption) *flags.Parser {
+	opt.HostOption = &HostOption{}
+	parser := flags.NewParser(opt, flags.HelpFlag|flags.PassDoubleDash)
+	parser.Usage = `auth logout - Remove the credentials of a GitLab host
+
+Synopsis:
+  lab auth logout [--host <host>]`
+	return parser
+}
+
+type LogoutCommand struct {
+	UI     ui.UI
+	Config *config.Config
+}
+
+func (c *LogoutCommand) Synopsis() string {
+	return "Remove the credentials of a GitLab host"
+}
+
+func (c *LogoutCommand) Help() string {
+	buf := &bytes.Buffer{}
+	var opt LogoutOption
+	parser := newLogoutOptionParser(&opt)
+	parser.WriteHelp(buf)
+	return buf.String()
+}
+
+func (c *LogoutCommand) Run(args []string) int {
+	var opt LogoutOption
+	parser := newLogoutOptionParser(&opt)
+	if _, err := parser.ParseArgs(args); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+
+	domain, err := opt.HostOption.getHost(c.Config)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+	if !c.Config.HasDomain(domain) {
+		c.UI.Error(fmt.Sprintf("Not logged in to [%s]", domain))
+		return ExitCodeError
+	}
+
+	if err := c.Config.DeleteToken(domain); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+	if err := c.Config.Save(); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+	c.UI.Message(fmt.Sprintf("Logged out of %s", domain))
+	return ExitCodeOK
+}
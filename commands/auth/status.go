@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/lighttiger2505/lab/commands/internal"
+	"github.com/lighttiger2505/lab/internal/api"
+	"github.com/lighttiger2505/lab/internal/config"
+	"github.com/lighttiger2505/lab/internal/gitutil"
+	"github.com/lighttiger2505/lab/internal/ui"
+	"github.com/ryanuber/columnize"
+)
+
+type StatusOption struct{}
+
+func newStatusOptionParser(opt *StatusOption) *flags.Parser {
+	parser := flags.NewParser(opt, flags.HelpFlag|flags.PassDoubleDash)
+	parser.Usage = `auth status - Show the authentication status of every profile
+
+Synopsis:
+  lab auth status`
+	return parser
+}
+
+type StatusCommand struct {
+	UI            ui.UI
+	Config        *config.Config
+	ClientFactory api.APIClientFactory
+}
+
+func (c *StatusCommand) Synopsis() string {
+	return "Show the authentication status of every profile"
+}
+
+func (c *StatusCommand) Help() string {
+	buf := &bytes.Buffer{}
+	var opt StatusOption
+	parser := newStatusOptionParser(&opt)
+	parser.WriteHelp(buf)
+	return buf.String()
+}
+
+func (c *StatusCommand) Run(args []string) int {
+	var opt StatusOption
+	parser := newStatusOptionParser(&opt)
+	if _, err := parser.ParseArgs(args); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+
+	domains := c.Config.Domains()
+	if len(domains) == 0 {
+		c.UI.Error("Not logged in to any host. Please run \"lab auth login\"")
+		return ExitCodeError
+	}
+
+	exitCode := ExitCodeOK
+	outputs := []string{}
+	for _, domain := range domains {
+		output, ok := c.status(domain)
+		if !ok {
+			exitCode = ExitCodeError
+		}
+		outputs = append(outputs, output)
+	}
+	c.UI.Message(columnize.SimpleFormat(outputs))
+	return exitCode
+}
+
+func (c *StatusCommand) status(domain string) (string, bool) {
+	name := domain
+	if domain == c.Config.DefaultProfile {
+		name = domain + " (default)"
+	}
+
+	token, err := c.Config.GetToken(domain)
+	if err != nil {
+		return strings.Join([]string{name, "-", fmt.Sprintf("error: %s", err)}, "|"), false
+	}
+	if token == "" {
+		return strings.Join([]string{name, "-", "not logged in"}, "|"), false
+	}
+
+	profile, err := c.Config.GetProfile(domain)
+	if err != nil {
+		return strings.Join([]string{name, "-", fmt.Sprintf("error: %s", err)}, "|"), false
+	}
+	user, scopes, err := internal.ValidateToken(c.ClientFactory, domain, token, gitutil.TokenType(profile))
+	if err != nil {
+		return strings.Join([]string{name, "-", "invalid token"}, "|"), false
+	}
+	status := fmt.Sprintf("valid token (scopes: %s)", scopesString(scopes))
+	if profile.IsOAuth() {
+		status = "valid OAuth token"
+	}
+	return strings.Join([]string{
+		name,
+		"@" + user.Username,
+		status,
+	}, "|"), true
+}
@@ -0,0 +1,197 @@
+package board
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/lighttiger2505/lab/commands/internal"
+	"github.com/lighttiger2505/lab/internal/api"
+	"github.com/lighttiger2505/lab/internal/gitutil"
+	"github.com/lighttiger2505/lab/internal/ui"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+const (
+	ExitCodeOK    int = iota //0
+	ExitCodeError int = iota //1
+)
+
+type Option struct {
+	ProjectProfileOption *internal.ProjectProfileOption `group:"Project, Profile Options"`
+	ShowOption           *ShowOption                    `group:"Show Options"`
+	MoveOption           *MoveOption                    `group:"Move Options"`
+}
+
+type ShowOption struct {
+	Num   int `short:"n" long:"num" value-name:"<num>" default:"20" default-mask:"20" description:"Limit the number of issue to output in each list."`
+	Width int `short:"w" long:"width" value-name:"<width>" default:"30" default-mask:"30" description:"The width of each list."`
+}
+
+type MoveOption struct {
+	Board int `long:"board" value-name:"<board id>" description:"The board to move the issue on. The first board of the project by default"`
+}
+
+func newOptionParser(opt *Option) *flags.Parser {
+	opt.ProjectProfileOption = &internal.ProjectProfileOption{}
+	opt.ShowOption = &ShowOption{}
+	opt.MoveOption = &MoveOption{}
+	parser := flags.NewParser(opt, flags.HelpFlag|flags.PassDoubleDash)
+	parser.Usage = `board - Show an issue board, and move an issue between its lists
+
+Synopsis:
+  # Show the board, the first board of the project by default
+  lab board [<board id>] [-n <num>] [-w <width>]
+
+  # Move the issue to the list, given by the label name, "open" or "closed"
+  lab board move <issue id> <list> [--board=<board id>]`
+	return parser
+}
+
+type BoardCommand struct {
+	UI              ui.UI
+	RemoteCollecter gitutil.Collecter
+	ClientFactory   api.APIClientFactory
+}
+
+func (c *BoardCommand) Synopsis() string {
+	return "Show an issue board, and move an issue between its lists"
+}
+
+func (c *BoardCommand) Help() string {
+	buf := &bytes.Buffer{}
+	var opt Option
+	parser := newOptionParser(&opt)
+	parser.WriteHelp(buf)
+	return buf.String()
+}
+
+func (c *BoardCommand) Run(args []string) int {
+	var opt Option
+	parser := newOptionParser(&opt)
+	parseArgs, err := parser.ParseArgs(args)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+
+	pInfo, err := c.RemoteCollecter.CollectTarget(
+		opt.ProjectProfileOption.Project,
+		opt.ProjectProfileOption.Profile,
+	)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+
+	if err := c.ClientFactory.Init(pInfo.ApiUrl(), pInfo.Token, pInfo.TokenType); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+
+	method, err := c.createMethod(parseArgs, opt, pInfo)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+	res, err := method.Process()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+
+	if res != "" {
+		c.UI.Message(res)
+	}
+
+	return ExitCodeOK
+}
+
+func (c *BoardCommand) createMethod(args []string, opt Option, pInfo *gitutil.GitLabProjectInfo) (internal.Method, error) {
+	if len(args) > 0 && args[0] == "move" {
+		if len(args) != 3 {
+			return nil, fmt.Errorf("Invalid args, please input issue id and list")
+		}
+		iid, err := strconv.Atoi(args[1])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid args, please input issue id")
+		}
+		return &moveMethod{
+			boardClient: c.ClientFactory.GetBoardClient(),
+			issueClient: c.ClientFactory.GetIssueClient(),
+			project:     pInfo.Project,
+			boardID:     opt.MoveOption.Board,
+			iid:         iid,
+			list:        args[2],
+		}, nil
+	}
+
+	id, err := validID(args)
+	if err != nil {
+		return nil, err
+	}
+	return &showMethod{
+		boardClient: c.ClientFactory.GetBoardClient(),
+		issueClient: c.ClientFactory.GetIssueClient(),
+		opt:         opt.ShowOption,
+		project:     pInfo.Project,
+		id:          id,
+	}, nil
+}
+
+func validID(args []string) (int, error) {
+	if len(args) < 1 {
+		return 0, nil
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return 0, fmt.Errorf("Invalid args, please input board id.")
+	}
+	return id, nil
+}
+
+// findBoard returns the board of the ID, or the first board of the project for the ID 0.
+func findBoard(client api.Board, project string, id int) (*gitlab.IssueBoard, error) {
+	if id > 0 {
+		return client.GetBoard(project, id)
+	}
+	boards, err := client.ListBoards(project)
+	if err != nil {
+		return nil, err
+	}
+	if len(boards) == 0 {
+		return nil, fmt.Errorf("Not found board in %s", project)
+	}
+	return boards[0], nil
+}
+
+// listLabels returns the labels of the board lists in the order of the board.
+// The lists without a label, e.g. assignee lists, are skipped.
+func listLabels(board *gitlab.IssueBoard) []string {
+	lists := append([]*gitlab.BoardList{}, board.Lists...)
+	sort.SliceStable(lists, func(i, j int) bool {
+		return lists[i].Position < lists[j].Position
+	})
+
+	labels := []string{}
+	for _, list := range lists {
+		if list.Label != nil {
+			labels = append(labels, list.Label.Name)
+		}
+	}
+	return labels
+}
+
+// findFold returns the value written in the values, or the empty string when it is not found.
+func findFold(values []string, value string) string {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return v
+		}
+	}
+	return ""
+}
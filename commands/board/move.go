@@ -0,0 +1,73 @@
+package board
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lighttiger2505/lab/internal/api"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+type moveMethod struct {
+	boardClient api.Board
+	issueClient api.Issue
+	project     string
+	boardID     int
+	iid         int
+	list        string
+}
+
+func (m *moveMethod) Process() (string, error) {
+	board, err := findBoard(m.boardClient, m.project, m.boardID)
+	if err != nil {
+		return "", err
+	}
+	issue, err := m.issueClient.GetIssue(m.iid, m.project)
+	if err != nil {
+		return "", err
+	}
+
+	updateOpt, err := makeMoveOption(issue, listLabels(board), m.list)
+	if err != nil {
+		return "", err
+	}
+	if _, err := m.issueClient.UpdateIssue(updateOpt, m.iid, m.project); err != nil {
+		return "", err
+	}
+	return "", nil
+}
+
+// makeMoveOption replaces the list label of the issue by the label of the list, as GitLab does on the board.
+// Moving to the closed list closes the issue, and moving out of it reopens the issue.
+func makeMoveOption(issue *gitlab.Issue, labels []string, list string) (*gitlab.UpdateIssueOptions, error) {
+	target := ""
+	if !strings.EqualFold(list, openList) && !strings.EqualFold(list, closedList) {
+		target = findFold(labels, list)
+		if target == "" {
+			lists := append(append([]string{openList}, labels...), closedList)
+			return nil, fmt.Errorf("Not found list, [%s]. Choose from %s", list, strings.Join(lists, ", "))
+		}
+	}
+
+	issueLabels := gitlab.Labels{}
+	for _, label := range issue.Labels {
+		if findFold(labels, label) == "" {
+			issueLabels = append(issueLabels, label)
+		}
+	}
+	if target != "" {
+		issueLabels = append(issueLabels, target)
+	}
+
+	opt := &gitlab.UpdateIssueOptions{
+		Labels: &issueLabels,
+	}
+	if strings.EqualFold(list, closedList) {
+		if issue.State != "closed" {
+			opt.StateEvent = gitlab.String("close")
+		}
+	} else if issue.State == "closed" {
+		opt.StateEvent = gitlab.String("reopen")
+	}
+	return opt, nil
+}
@@ -0,0 +1,74 @@
+package board
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+func Test_makeMoveOption(t *testing.T) {
+	lists := []string{"To Do", "Doing"}
+	tests := []struct {
+		name       string
+		issue      *gitlab.Issue
+		list       string
+		labels     gitlab.Labels
+		stateEvent string
+		wantErr    bool
+	}{
+		{
+			name:   "to label list",
+			issue:  &gitlab.Issue{State: "opened", Labels: gitlab.Labels{"bug", "To Do"}},
+			list:   "doing",
+			labels: gitlab.Labels{"bug", "Doing"},
+		},
+		{
+			name:   "to open list",
+			issue:  &gitlab.Issue{State: "opened", Labels: gitlab.Labels{"Doing", "bug"}},
+			list:   "open",
+			labels: gitlab.Labels{"bug"},
+		},
+		{
+			name:       "to closed list",
+			issue:      &gitlab.Issue{State: "opened", Labels: gitlab.Labels{"Doing"}},
+			list:       "Closed",
+			labels:     gitlab.Labels{},
+			stateEvent: "close",
+		},
+		{
+			name:       "reopen",
+			issue:      &gitlab.Issue{State: "closed", Labels: gitlab.Labels{}},
+			list:       "To Do",
+			labels:     gitlab.Labels{"To Do"},
+			stateEvent: "reopen",
+		},
+		{
+			name:    "unknown list",
+			issue:   &gitlab.Issue{State: "opened"},
+			list:    "Review",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := makeMoveOption(tt.issue, lists, tt.list)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("makeMoveOption() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := cmp.Diff(*got.Labels, tt.labels); diff != "" {
+				t.Errorf("invalid labels (-got +want)\n%s", diff)
+			}
+			stateEvent := ""
+			if got.StateEvent != nil {
+				stateEvent = *got.StateEvent
+			}
+			if stateEvent != tt.stateEvent {
+				t.Errorf("invalid state event, got %q, want %q", stateEvent, tt.stateEvent)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package board
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/lighttiger2505/lab/internal/api"
+	"github.com/ryanuber/columnize"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+// The lists that GitLab shows on every board, but does not return as the board lists
+const (
+	openList   = "Open"
+	closedList = "Closed"
+)
+
+// openPerPage is the page size to find the issues of the open list
+const openPerPage = 100
+
+type showMethod struct {
+	boardClient api.Board
+	issueClient api.Issue
+	opt         *ShowOption
+	project     string
+	id          int
+}
+
+// column is a list of the board with its issues
+type column struct {
+	name   string
+	issues []*gitlab.Issue
+}
+
+func (m *showMethod) Process() (string, error) {
+	board, err := findBoard(m.boardClient, m.project, m.id)
+	if err != nil {
+		return "", err
+	}
+	labels := listLabels(board)
+
+	opened, err := m.openIssues(board, labels)
+	if err != nil {
+		return "", err
+	}
+	columns := []*column{&column{name: openList, issues: opened}}
+
+	for _, label := range labels {
+		issues, err := m.issueClient.GetProjectIssues(m.makeListOption(board, "opened", []string{label}, m.opt.Num), m.project)
+		if err != nil {
+			return "", err
+		}
+		columns = append(columns, &column{name: label, issues: issues})
+	}
+
+	closed, err := m.issueClient.GetProjectIssues(m.makeListOption(board, "closed", nil, m.opt.Num), m.project)
+	if err != nil {
+		return "", err
+	}
+	columns = append(columns, &column{name: closedList, issues: closed})
+
+	header := fmt.Sprintf("%d %s", board.ID, board.Name)
+	return strings.Join([]string{header, "", boardOutput(columns, m.opt.Width)}, "\n"), nil
+}
+
+// openIssues returns the issues of the open list, that are the open issues without the labels of the lists.
+// The issues are read page by page until the list is filled, because the labeled issues are skipped.
+func (m *showMethod) openIssues(board *gitlab.IssueBoard, labels []string) ([]*gitlab.Issue, error) {
+	opt := m.makeListOption(board, "opened", nil, openPerPage)
+	results := []*gitlab.Issue{}
+	for {
+		issues, err := m.issueClient.GetProjectIssues(opt, m.project)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, withoutLabels(issues, labels, m.opt.Num-len(results))...)
+		if len(results) >= m.opt.Num || len(issues) < openPerPage {
+			return results, nil
+		}
+		opt.Page++
+	}
+}
+
+// makeListOption lists the issues of the board scope, that is the milestone of the board.
+func (m *showMethod) makeListOption(board *gitlab.IssueBoard, state string, labels []string, num int) *gitlab.ListProjectIssuesOptions {
+	opt := &gitlab.ListProjectIssuesOptions{
+		ListOptions: gitlab.ListOptions{
+			Page:    1,
+			PerPage: num,
+		},
+		State:   gitlab.String(state),
+		Labels:  gitlab.Labels(labels),
+		OrderBy: gitlab.String("relative_position"),
+		Sort:    gitlab.String("asc"),
+	}
+	if board.Milestone != nil {
+		opt.Milestone = gitlab.String(board.Milestone.Title)
+	}
+	return opt
+}
+
+func withoutLabels(issues []*gitlab.Issue, labels []string, num int) []*gitlab.Issue {
+	results := []*gitlab.Issue{}
+	for _, issue := range issues {
+		if len(results) == num {
+			break
+		}
+		inList := false
+		for _, label := range issue.Labels {
+			if findFold(labels, label) != "" {
+				inList = true
+				break
+			}
+		}
+		if !inList {
+			results = append(results, issue)
+		}
+	}
+	return results
+}
+
+// boardOutput renders the lists as columns, the issue titles are cut to the width.
+func boardOutput(columns []*column, width int) string {
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	header := []string{}
+	rows := 0
+	for _, c := range columns {
+		header = append(header, cyan(fmt.Sprintf("%s (%d)", truncate(c.name, width-5), len(c.issues))))
+		if len(c.issues) > rows {
+			rows = len(c.issues)
+		}
+	}
+
+	outputs := []string{strings.Join(header, "|")}
+	for i := 0; i < rows; i++ {
+		cells := []string{}
+		for _, c := range columns {
+			cell := ""
+			if i < len(c.issues) {
+				cell = truncate(fmt.Sprintf("#%d %s", c.issues[i].IID, c.issues[i].Title), width)
+			}
+			cells = append(cells, cell)
+		}
+		outputs = append(outputs, strings.Join(cells, "|"))
+	}
+	return columnize.SimpleFormat(outputs)
+}
+
+func truncate(value string, width int) string {
+	// The separator of columnize is removed from the title
+	value = strings.Replace(value, "|", "/", -1)
+	runes := []rune(value)
+	if width < 4 || len(runes) <= width {
+		return value
+	}
+	return string(runes[:width-3]) + "..."
+}
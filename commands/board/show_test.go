@@ -0,0 +1,105 @@
+package board
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lighttiger2505/lab/internal/api"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+func Test_showMethod_Process(t *testing.T) {
+	boardClient := &api.MockBoardClient{
+		MockListBoards: func(project string) ([]*gitlab.IssueBoard, error) {
+			return []*gitlab.IssueBoard{
+				&gitlab.IssueBoard{
+					ID:        3,
+					Name:      "Development",
+					Milestone: &gitlab.Milestone{Title: "v1.0"},
+					Lists: []*gitlab.BoardList{
+						&gitlab.BoardList{Label: &gitlab.Label{Name: "Doing"}, Position: 1},
+						&gitlab.BoardList{Label: &gitlab.Label{Name: "To Do"}, Position: 0},
+						&gitlab.BoardList{Position: 2},
+					},
+				},
+			}, nil
+		},
+	}
+	issueClient := &api.MockLabIssueClient{
+		MockGetProjectIssues: func(opt *gitlab.ListProjectIssuesOptions, repositoryName string) ([]*gitlab.Issue, error) {
+			if *opt.Milestone != "v1.0" {
+				t.Errorf("issues must be in the milestone of the board, got %s", *opt.Milestone)
+			}
+			if *opt.State == "closed" {
+				return []*gitlab.Issue{&gitlab.Issue{IID: 1, Title: "done"}}, nil
+			}
+			if len(opt.Labels) == 0 {
+				return []*gitlab.Issue{
+					&gitlab.Issue{IID: 2, Title: "new"},
+					&gitlab.Issue{IID: 3, Title: "in progress", Labels: gitlab.Labels{"doing"}},
+					&gitlab.Issue{IID: 4, Title: "a very long title of the issue"},
+				}, nil
+			}
+			if opt.Labels[0] == "Doing" {
+				return []*gitlab.Issue{&gitlab.Issue{IID: 3, Title: "in progress"}}, nil
+			}
+			return []*gitlab.Issue{}, nil
+		},
+	}
+
+	m := &showMethod{
+		boardClient: boardClient,
+		issueClient: issueClient,
+		opt:         &ShowOption{Num: 20, Width: 20},
+		project:     "group/project",
+	}
+	got, err := m.Process()
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	want := strings.Join([]string{
+		"3 Development",
+		"",
+		"Open (2)              To Do (0)  Doing (1)       Closed (1)",
+		"#2 new                           #3 in progress  #1 done",
+		"#4 a very long ti...                             ",
+	}, "\n")
+	if got != want {
+		t.Errorf("Process() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func Test_showMethod_openIssues(t *testing.T) {
+	// The first page has only the labeled issues
+	pages := [][]*gitlab.Issue{{}, {}}
+	for i := 1; i <= openPerPage; i++ {
+		pages[0] = append(pages[0], &gitlab.Issue{IID: i, Labels: gitlab.Labels{"Doing"}})
+	}
+	pages[1] = []*gitlab.Issue{
+		&gitlab.Issue{IID: 101},
+		&gitlab.Issue{IID: 102, Labels: gitlab.Labels{"bug"}},
+	}
+
+	requested := 0
+	m := &showMethod{
+		issueClient: &api.MockLabIssueClient{
+			MockGetProjectIssues: func(opt *gitlab.ListProjectIssuesOptions, repositoryName string) ([]*gitlab.Issue, error) {
+				requested++
+				return pages[opt.Page-1], nil
+			},
+		},
+		opt:     &ShowOption{Num: 20},
+		project: "group/project",
+	}
+	got, err := m.openIssues(&gitlab.IssueBoard{}, []string{"Doing"})
+	if err != nil {
+		t.Fatalf("openIssues() error = %v", err)
+	}
+	if len(got) != 2 || got[0].IID != 101 || got[1].IID != 102 {
+		t.Errorf("openIssues() = %v", got)
+	}
+	if requested != 2 {
+		t.Errorf("requested %d pages, want 2", requested)
+	}
+}
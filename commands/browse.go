@@ -88,7 +88,7 @@ func (c *BrowseCommand) Run(args []string) int {
 		return ExitCodeError
 	}
 
-	if err := c.ClientFactory.Init(pInfo.ApiUrl(), pInfo.Token); err != nil {
+	if err := c.ClientFactory.Init(pInfo.ApiUrl(), pInfo.Token, pInfo.TokenType); err != nil {
 		c.UI.Error(err.Error())
 		return ExitCodeError
 	}
@@ -99,6 +99,7 @@ func (c *BrowseCommand) Run(args []string) int {
 		return ExitCodeError
 	}
 
+	// The empty branch is the default branch of the project
 	var branch string
 	if isGitDir {
 		branch, err = c.getBranch(pInfo)
@@ -135,6 +136,7 @@ func (c *BrowseCommand) Run(args []string) int {
 	return ExitCodeOK
 }
 
+// getBranch returns the current branch, or empty for the default branch when the current branch is not pushed.
 func (c *BrowseCommand) getBranch(pInfo *gitutil.GitLabProjectInfo) (string, error) {
 	localBranch, err := c.GitClient.CurrentRemoteBranch()
 	if err != nil {
@@ -144,12 +146,21 @@ func (c *BrowseCommand) getBranch(pInfo *gitutil.GitLabProjectInfo) (string, err
 	branchClient := c.ClientFactory.GetBranchClient()
 	remoteBranch, _ := branchClient.GetBranch(pInfo.Project, localBranch)
 	if remoteBranch == nil {
-		return "master", nil
+		return "", nil
 	}
 
 	return localBranch, nil
 }
 
+// defaultBranch returns the default branch of the project, that is only needed to browse a file.
+func (c *BrowseCommand) defaultBranch(pInfo *gitutil.GitLabProjectInfo) string {
+	branch, err := c.ClientFactory.GetProjectClient().DefaultBranch(pInfo.Project)
+	if err != nil {
+		return api.DefaultBranchFallback
+	}
+	return branch
+}
+
 func (c *BrowseCommand) getURL(args []string, pInfo *gitutil.GitLabProjectInfo, branch string, opt *BrowseOption) (string, error) {
 	if len(args) > 0 {
 		arg := args[0]
@@ -174,6 +185,9 @@ func (c *BrowseCommand) getURL(args []string, pInfo *gitutil.GitLabProjectInfo,
 		if err != nil {
 			return "", err
 		}
+		if branch == "" {
+			branch = c.defaultBranch(pInfo)
+		}
 
 		if opt.Subpage != "" {
 			return pInfo.BranchFileWithLine(branch, gitAbsPath, opt.Subpage), nil
@@ -186,7 +200,7 @@ func (c *BrowseCommand) getURL(args []string, pInfo *gitutil.GitLabProjectInfo,
 	}
 
 	// TODO You need to ignore the branch when the project is specified as an option
-	if branch == "master" {
+	if branch == "" {
 		return pInfo.RepositoryUrl(), nil
 	}
 	return pInfo.BranchUrl(branch), nil
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/lighttiger2505/lab/git"
@@ -40,6 +41,13 @@ var mockAPIClientFactory = &api.MockAPIClientFactory{
 			},
 		}
 	},
+	MockGetProjectClient: func() api.Project {
+		return &api.MockProjectClient{
+			MockDefaultBranch: func(project string) (string, error) {
+				return "master", nil
+			},
+		}
+	},
 }
 
 func TestBrowseCommandRun(t *testing.T) {
@@ -57,3 +65,32 @@ func TestBrowseCommandRun(t *testing.T) {
 		t.Fatalf("wrong exit code. errors: \n%s", mockUI.ErrorWriter.String())
 	}
 }
+
+func TestBrowseCommand_defaultBranch(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "default branch", want: "develop"},
+		{name: "fallback on error", err: errors.New("404 Not Found"), want: api.DefaultBranchFallback},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := BrowseCommand{
+				ClientFactory: &api.MockAPIClientFactory{
+					MockGetProjectClient: func() api.Project {
+						return &api.MockProjectClient{
+							MockDefaultBranch: func(project string) (string, error) {
+								return "develop", tt.err
+							},
+						}
+					},
+				},
+			}
+			if got := c.defaultBranch(&gitutil.GitLabProjectInfo{Project: "group/project"}); got != tt.want {
+				t.Errorf("defaultBranch() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
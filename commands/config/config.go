@@ -2,11 +2,17 @@ package config
 
 import (
 	"bytes"
+	"path/filepath"
+	"strings"
 
 	flags "github.com/jessevdk/go-flags"
+	"github.com/lighttiger2505/lab/commands/internal"
+	"github.com/lighttiger2505/lab/git"
 	"github.com/lighttiger2505/lab/internal/config"
 	"github.com/lighttiger2505/lab/internal/editor"
+	"github.com/lighttiger2505/lab/internal/gitutil"
 	"github.com/lighttiger2505/lab/internal/ui"
+	"github.com/ryanuber/columnize"
 )
 
 const (
@@ -15,10 +21,13 @@ const (
 )
 
 type Option struct {
-	List bool `short:"l" long:"list" description:"Show config."`
+	ProjectProfileOption *internal.ProjectProfileOption `group:"Project, Profile Options"`
+	List                 bool                           `short:"l" long:"list" description:"Show config."`
+	ShowEffective        bool                           `long:"show-effective" description:"Show the settings merged with .lab.yml of the repository, and where each value came from."`
 }
 
 func newOptionParser(opt *Option) *flags.Parser {
+	opt.ProjectProfileOption = &internal.ProjectProfileOption{}
 	parser := flags.NewParser(opt, flags.HelpFlag|flags.PassDoubleDash)
 	parser.Usage = `config - Edit and Show config
 
@@ -27,13 +36,29 @@ Synopsis:
   lab config
 
   # Show config
-  lab config -l`
+  lab config -l
+
+  # Show effective settings
+  lab config --show-effective [--profile <profile>]
+
+  # Get and set a key
+  lab config get [--profile <profile>] <key>
+  lab config set [--profile <profile>] <key> <value>
+
+  # Manage profiles
+  lab config profiles
+  lab config use <profile>
+  lab config add-profile <profile>
+
+  # Check the config file and tokens
+  lab config validate`
 	return parser
 }
 
 type ConfigCommand struct {
-	UI     ui.UI
-	Config *config.Config
+	UI              ui.UI
+	Config          *config.Config
+	RemoteCollecter gitutil.Collecter
 }
 
 func (c *ConfigCommand) Synopsis() string {
@@ -57,7 +82,14 @@ func (c *ConfigCommand) Run(args []string) int {
 		return ExitCodeError
 	}
 
-	if opt.List {
+	if opt.ShowEffective {
+		res, err := c.showEffective(opt.ProjectProfileOption)
+		if err != nil {
+			c.UI.Error(err.Error())
+			return ExitCodeError
+		}
+		c.UI.Message(res)
+	} else if opt.List {
 		cfgStr, err := c.Config.Read()
 		if err != nil {
 			c.UI.Error(err.Error())
@@ -70,3 +102,51 @@ func (c *ConfigCommand) Run(args []string) int {
 
 	return ExitCodeOK
 }
+
+func (c *ConfigCommand) showEffective(opt *internal.ProjectProfileOption) (string, error) {
+	pInfo, err := c.RemoteCollecter.CollectTarget(opt.Project, opt.Profile)
+	if err != nil {
+		return "", err
+	}
+
+	profile := &config.Profile{}
+	if c.Config.HasDomain(pInfo.Domain) {
+		profile, err = c.Config.GetProfile(pInfo.Domain)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	var repo *config.RepositoryConfig
+	var repoPath string
+	isGitDir, err := git.IsGitDirReverseTop()
+	if err != nil {
+		return "", err
+	}
+	if isGitDir {
+		root, err := git.Root()
+		if err != nil {
+			return "", err
+		}
+		repoPath = filepath.Join(root, config.RepositoryConfigFile)
+		repo, err = config.LoadRepositoryConfig(root)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	settings := config.EffectiveSettings(pInfo.Domain, profile, c.Config.Path(), repo, repoPath)
+	return columnize.SimpleFormat(effectiveOutput(settings)), nil
+}
+
+func effectiveOutput(settings []*config.EffectiveSetting) []string {
+	outputs := []string{}
+	for _, s := range settings {
+		value := s.Value
+		if value == "" {
+			value = "-"
+		}
+		outputs = append(outputs, strings.Join([]string{s.Key, value, s.Source}, "|"))
+	}
+	return outputs
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lighttiger2505/lab/internal/api"
+	"github.com/lighttiger2505/lab/internal/config"
+	"github.com/lighttiger2505/lab/internal/ui"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+func testConfig() *config.Config {
+	return &config.Config{
+		Profiles: map[string]config.Profile{
+			"gitlab.com":         {Token: "validtoken", DefaultProject: "hoge/soge"},
+			"gitlab.example.com": {Token: "invalidtoken"},
+		},
+		DefaultProfile: "gitlab.com",
+	}
+}
+
+func TestGetCommand_Run(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		want     string
+		wantCode int
+	}{
+		{name: "default profile", args: []string{"default_project"}, want: "hoge/soge\n", wantCode: ExitCodeOK},
+		{name: "profile", args: []string{"--profile", "gitlab.example.com", "token"}, want: "invalidtoken\n", wantCode: ExitCodeOK},
+		{name: "top level", args: []string{"default_profile"}, want: "gitlab.com\n", wantCode: ExitCodeOK},
+		{name: "unknown key", args: []string{"unknown"}, want: "", wantCode: ExitCodeError},
+		{name: "no key", args: []string{}, want: "", wantCode: ExitCodeError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUI := ui.NewMockUi()
+			c := &GetCommand{UI: mockUI, Config: testConfig()}
+			if code := c.Run(tt.args); code != tt.wantCode {
+				t.Fatalf("bad exit code, got %d, want %d\n%s", code, tt.wantCode, mockUI.ErrorWriter.String())
+			}
+			if got := mockUI.Writer.String(); got != tt.want {
+				t.Errorf("bad output, got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetCommand_set(t *testing.T) {
+	cfg := testConfig()
+	c := &SetCommand{UI: ui.NewMockUi(), Config: cfg}
+	if err := c.set(&ProfileOption{}, "target_branch", "develop"); err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.Profiles["gitlab.com"].TargetBranch; got != "develop" {
+		t.Errorf("target_branch is not changed, %s", got)
+	}
+	if err := c.set(&ProfileOption{Profile: "unknown.com"}, "target_branch", "develop"); err == nil {
+		t.Error("set() want error for unknown profile")
+	}
+}
+
+func TestProfilesOutput(t *testing.T) {
+	got := profilesOutput(testConfig())
+	want := []string{
+		"*|gitlab.com|hoge/soge",
+		" |gitlab.example.com|-",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("bad output, got %q, want %q", got, want)
+	}
+}
+
+func TestValidateCommand_checkToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		domain string
+		user   *gitlab.User
+		err    error
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "valid",
+			domain: "gitlab.com",
+			user:   &gitlab.User{Username: "lighttiger2505"},
+			want:   "ok (@lighttiger2505)",
+			wantOK: true,
+		},
+		{
+			name:   "invalid",
+			domain: "gitlab.example.com",
+			err:    errors.New("401 Unauthorized"),
+			want:   "error: invalid token, 401 Unauthorized",
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &ValidateCommand{
+				UI:     ui.NewMockUi(),
+				Config: testConfig(),
+				ClientFactory: &api.MockAPIClientFactory{
+					MockGetUserClient: func() api.User {
+						return &api.MockUserClient{
+							MockCurrentUser: func() (*gitlab.User, error) {
+								return tt.user, tt.err
+							},
+							MockCurrentTokenScopes: func() ([]string, error) {
+								return []string{"api"}, nil
+							},
+						}
+					},
+				},
+			}
+			got, ok := c.checkToken(tt.domain)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("checkToken() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/lighttiger2505/lab/internal/config"
+	"github.com/lighttiger2505/lab/internal/ui"
+)
+
+type ProfileOption struct {
+	Profile string `long:"profile" value-name:"<profile>" description:"The profile to be processed. Defaults to default_profile"`
+}
+
+// getProfile returns the profile given by the option, or the default profile of the config.
+func (o *ProfileOption) getProfile(cfg *config.Config) (string, error) {
+	if o.Profile != "" {
+		return o.Profile, nil
+	}
+	if cfg.DefaultProfile != "" {
+		return cfg.DefaultProfile, nil
+	}
+	return "", fmt.Errorf("Please specify the profile with --profile")
+}
+
+type GetOption struct {
+	ProfileOption *ProfileOption `group:"Profile Options"`
+}
+
+func newGetOptionParser(opt *GetOption) *flags.Parser {
+	opt.ProfileOption = &ProfileOption{}
+	parser := flags.NewParser(opt, flags.HelpFlag|flags.PassDoubleDash)
+	parser.Usage = `config get - Show the value of a config key
+
+Synopsis:
+  lab config get [--profile <profile>] <key>`
+	return parser
+}
+
+type GetCommand struct {
+	UI     ui.UI
+	Config *config.Config
+}
+
+func (c *GetCommand) Synopsis() string {
+	return "Show the value of a config key"
+}
+
+func (c *GetCommand) Help() string {
+	buf := &bytes.Buffer{}
+	var opt GetOption
+	parser := newGetOptionParser(&opt)
+	parser.WriteHelp(buf)
+	return buf.String()
+}
+
+func (c *GetCommand) Run(args []string) int {
+	var opt GetOption
+	parser := newGetOptionParser(&opt)
+	parsedArgs, err := parser.ParseArgs(args)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+	if len(parsedArgs) != 1 {
+		c.UI.Error("Please specify a key")
+		return ExitCodeError
+	}
+	key := parsedArgs[0]
+
+	domain := ""
+	if !config.IsGlobalKey(key) {
+		domain, err = opt.ProfileOption.getProfile(c.Config)
+		if err != nil {
+			c.UI.Error(err.Error())
+			return ExitCodeError
+		}
+	}
+
+	value, err := c.Config.Get(domain, key)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+	c.UI.Message(value)
+	return ExitCodeOK
+}
@@ -0,0 +1,162 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/lighttiger2505/lab/internal/config"
+	"github.com/lighttiger2505/lab/internal/ui"
+	"github.com/ryanuber/columnize"
+)
+
+type ProfilesCommand struct {
+	UI     ui.UI
+	Config *config.Config
+}
+
+func newProfilesOptionParser() *flags.Parser {
+	parser := flags.NewParser(&struct{}{}, flags.HelpFlag|flags.PassDoubleDash)
+	parser.Usage = `config profiles - List the profiles
+
+Synopsis:
+  lab config profiles`
+	return parser
+}
+
+func (c *ProfilesCommand) Synopsis() string {
+	return "List the profiles"
+}
+
+func (c *ProfilesCommand) Help() string {
+	buf := &bytes.Buffer{}
+	newProfilesOptionParser().WriteHelp(buf)
+	return buf.String()
+}
+
+func (c *ProfilesCommand) Run(args []string) int {
+	if _, err := newProfilesOptionParser().ParseArgs(args); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+	if len(c.Config.Profiles) == 0 {
+		c.UI.Message("No profiles. Please run \"lab auth login\" or \"lab config add-profile\"")
+		return ExitCodeOK
+	}
+	c.UI.Message(columnize.SimpleFormat(profilesOutput(c.Config)))
+	return ExitCodeOK
+}
+
+func profilesOutput(cfg *config.Config) []string {
+	outputs := []string{}
+	for _, domain := range cfg.Domains() {
+		mark := " "
+		if domain == cfg.DefaultProfile {
+			mark = "*"
+		}
+		profile := cfg.Profiles[domain]
+		project := profile.DefaultProject
+		if project == "" {
+			project = "-"
+		}
+		outputs = append(outputs, strings.Join([]string{mark, domain, project}, "|"))
+	}
+	return outputs
+}
+
+type UseCommand struct {
+	UI     ui.UI
+	Config *config.Config
+}
+
+func newUseOptionParser() *flags.Parser {
+	parser := flags.NewParser(&struct{}{}, flags.HelpFlag|flags.PassDoubleDash)
+	parser.Usage = `config use - Change the default profile
+
+Synopsis:
+  lab config use <profile>`
+	return parser
+}
+
+func (c *UseCommand) Synopsis() string {
+	return "Change the default profile"
+}
+
+func (c *UseCommand) Help() string {
+	buf := &bytes.Buffer{}
+	newUseOptionParser().WriteHelp(buf)
+	return buf.String()
+}
+
+func (c *UseCommand) Run(args []string) int {
+	parsedArgs, err := newUseOptionParser().ParseArgs(args)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+	if len(parsedArgs) != 1 {
+		c.UI.Error("Please specify a profile")
+		return ExitCodeError
+	}
+
+	if err := c.Config.UseProfile(parsedArgs[0]); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+	if err := c.Config.Save(); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+	c.UI.Message(fmt.Sprintf("Switched to profile %s", parsedArgs[0]))
+	return ExitCodeOK
+}
+
+type AddProfileCommand struct {
+	UI     ui.UI
+	Config *config.Config
+}
+
+func newAddProfileOptionParser() *flags.Parser {
+	parser := flags.NewParser(&struct{}{}, flags.HelpFlag|flags.PassDoubleDash)
+	parser.Usage = `config add-profile - Add an empty profile
+
+Synopsis:
+  lab config add-profile <profile>
+
+  # Then set the values
+  lab config set --profile <profile> token_command "pass show gitlab/token"`
+	return parser
+}
+
+func (c *AddProfileCommand) Synopsis() string {
+	return "Add an empty profile"
+}
+
+func (c *AddProfileCommand) Help() string {
+	buf := &bytes.Buffer{}
+	newAddProfileOptionParser().WriteHelp(buf)
+	return buf.String()
+}
+
+func (c *AddProfileCommand) Run(args []string) int {
+	parsedArgs, err := newAddProfileOptionParser().ParseArgs(args)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+	if len(parsedArgs) != 1 {
+		c.UI.Error("Please specify a profile")
+		return ExitCodeError
+	}
+
+	if err := c.Config.AddProfile(parsedArgs[0]); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+	if err := c.Config.Save(); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+	return ExitCodeOK
+}
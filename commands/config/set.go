@@ -0,0 +1,83 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/lighttiger2505/lab/internal/config"
+	"github.com/lighttiger2505/lab/internal/ui"
+)
+
+type SetOption struct {
+	ProfileOption *ProfileOption `group:"Profile Options"`
+}
+
+func newSetOptionParser(opt *SetOption) *flags.Parser {
+	opt.ProfileOption = &ProfileOption{}
+	parser := flags.NewParser(opt, flags.HelpFlag|flags.PassDoubleDash)
+	parser.Usage = `config set - Change the value of a config key
+
+Synopsis:
+  lab config set [--profile <profile>] <key> <value>
+
+  # Unset the key
+  lab config set [--profile <profile>] <key> ""`
+	return parser
+}
+
+type SetCommand struct {
+	UI     ui.UI
+	Config *config.Config
+}
+
+func (c *SetCommand) Synopsis() string {
+	return "Change the value of a config key"
+}
+
+func (c *SetCommand) Help() string {
+	buf := &bytes.Buffer{}
+	var opt SetOption
+	parser := newSetOptionParser(&opt)
+	parser.WriteHelp(buf)
+	return buf.String()
+}
+
+func (c *SetCommand) Run(args []string) int {
+	var opt SetOption
+	parser := newSetOptionParser(&opt)
+	parsedArgs, err := parser.ParseArgs(args)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+	if len(parsedArgs) != 2 {
+		c.UI.Error("Please specify a key and a value")
+		return ExitCodeError
+	}
+
+	if err := c.set(opt.ProfileOption, parsedArgs[0], parsedArgs[1]); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+	if err := c.Config.Save(); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+	return ExitCodeOK
+}
+
+func (c *SetCommand) set(opt *ProfileOption, key, value string) error {
+	domain := ""
+	if !config.IsGlobalKey(key) {
+		var err error
+		domain, err = opt.getProfile(c.Config)
+		if err != nil {
+			return err
+		}
+		if !c.Config.HasDomain(domain) {
+			return fmt.Errorf("not found profile, [%s]. Please add it by \"lab config add-profile %s\"", domain, domain)
+		}
+	}
+	return c.Config.Set(domain, key, value)
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/lighttiger2505/lab/commands/internal"
+	"github.com/lighttiger2505/lab/internal/api"
+	"github.com/lighttiger2505/lab/internal/config"
+	"github.com/lighttiger2505/lab/internal/gitutil"
+	"github.com/lighttiger2505/lab/internal/ui"
+	"github.com/ryanuber/columnize"
+)
+
+type ValidateOption struct {
+	Offline bool `long:"offline" description:"Check only the config file, without calling the API"`
+}
+
+func newValidateOptionParser(opt *ValidateOption) *flags.Parser {
+	parser := flags.NewParser(opt, flags.HelpFlag|flags.PassDoubleDash)
+	parser.Usage = `config validate - Check the config file and the token of every profile
+
+Synopsis:
+  lab config validate [--offline]`
+	return parser
+}
+
+type ValidateCommand struct {
+	UI            ui.UI
+	Config        *config.Config
+	ClientFactory api.APIClientFactory
+}
+
+func (c *ValidateCommand) Synopsis() string {
+	return "Check the config file and the token of every profile"
+}
+
+func (c *ValidateCommand) Help() string {
+	buf := &bytes.Buffer{}
+	var opt ValidateOption
+	parser := newValidateOptionParser(&opt)
+	parser.WriteHelp(buf)
+	return buf.String()
+}
+
+func (c *ValidateCommand) Run(args []string) int {
+	var opt ValidateOption
+	parser := newValidateOptionParser(&opt)
+	if _, err := parser.ParseArgs(args); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+
+	exitCode := ExitCodeOK
+	for _, err := range c.Config.Validate() {
+		c.UI.Error(err.Error())
+		exitCode = ExitCodeError
+	}
+
+	if !opt.Offline {
+		outputs := []string{}
+		for _, domain := range c.Config.Domains() {
+			status, ok := c.checkToken(domain)
+			if !ok {
+				exitCode = ExitCodeError
+			}
+			outputs = append(outputs, strings.Join([]string{domain, status}, "|"))
+		}
+		if len(outputs) > 0 {
+			c.UI.Message(columnize.SimpleFormat(outputs))
+		}
+	}
+
+	if exitCode == ExitCodeOK {
+		c.UI.Message(fmt.Sprintf("%s is valid", c.Config.Path()))
+	}
+	return exitCode
+}
+
+func (c *ValidateCommand) checkToken(domain string) (string, bool) {
+	token, err := c.Config.GetToken(domain)
+	if err != nil {
+		return fmt.Sprintf("error: %s", err), false
+	}
+	if token == "" {
+		return "error: no token", false
+	}
+
+	profile, err := c.Config.GetProfile(domain)
+	if err != nil {
+		return fmt.Sprintf("error: %s", err), false
+	}
+	user, _, err := internal.ValidateToken(c.ClientFactory, domain, token, gitutil.TokenType(profile))
+	if err != nil {
+		return fmt.Sprintf("error: invalid token, %s", err), false
+	}
+	return fmt.Sprintf("ok (@%s)", user.Username), true
+}
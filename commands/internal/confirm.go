@@ -0,0 +1,25 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/lighttiger2505/lab/internal/ui"
+)
+
+// ConfirmDelete asks the user before deleting the target, unless yes is given by --yes.
+func ConfirmDelete(u ui.UI, target string, yes bool) error {
+	if yes {
+		return nil
+	}
+	if !ui.Interactive(u) {
+		return fmt.Errorf("Cannot delete %s without the confirmation in non-interactive mode, please use --yes", target)
+	}
+	ok, err := ui.Confirm(u, fmt.Sprintf("Delete %s? It cannot be undone.", target))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("Canceled deleting %s", target)
+	}
+	return nil
+}
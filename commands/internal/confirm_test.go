@@ -0,0 +1,37 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lighttiger2505/lab/internal/ui"
+)
+
+func TestConfirmDelete(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		yes     bool
+		noInput bool
+		wantErr bool
+	}{
+		{name: "accepted", input: "y\n"},
+		{name: "declined", input: "n\n", wantErr: true},
+		{name: "yes flag", yes: true, noInput: true},
+		{name: "non-interactive", noInput: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &ui.BasicUi{
+				Reader:      strings.NewReader(tt.input),
+				Writer:      &strings.Builder{},
+				ErrorWriter: &strings.Builder{},
+				NoInput:     tt.noInput,
+			}
+			err := ConfirmDelete(u, "issue #12 of group/project", tt.yes)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ConfirmDelete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package internal
 
+import "fmt"
+
 type ProjectProfileOption struct {
 	Project string `long:"project" value-name:"<group>/<name>" description:"Specify the project to be processed"`
 	Profile string `long:"profile" value-name:"<profile>" description:"Specify the profile defined in the config file"`
@@ -17,3 +19,89 @@ func (b *BrowseOption) HasBrowse() bool {
 	}
 	return false
 }
+
+type TimeTrackingOption struct {
+	Estimate   string `long:"estimate" value-name:"<duration>" description:"Set the time estimate, e.g. \"3h\" or \"1d 4h\""`
+	Spend      string `long:"spend" value-name:"<duration>" description:"Add the time spent, e.g. \"1h30m\". A negative duration (--spend=-30m) subtracts"`
+	Summary    string `long:"summary" value-name:"<summary>" description:"The summary of the time spent by --spend"`
+	ResetSpent bool   `long:"reset-spent" description:"Reset the time spent"`
+}
+
+func (t *TimeTrackingOption) HasTimeTracking() bool {
+	if t.Estimate != "" || t.Spend != "" || t.ResetSpent {
+		return true
+	}
+	return false
+}
+
+func (t *TimeTrackingOption) IsValid() error {
+	if t.Summary != "" && t.Spend == "" {
+		return fmt.Errorf("--summary requires --spend")
+	}
+	return nil
+}
+
+type SubscriptionOption struct {
+	Subscribe   bool `long:"subscribe" description:"Subscribe to the notifications"`
+	Unsubscribe bool `long:"unsubscribe" description:"Unsubscribe from the notifications"`
+}
+
+func (s *SubscriptionOption) HasSubscription() bool {
+	return s.Subscribe || s.Unsubscribe
+}
+
+func (s *SubscriptionOption) IsValid() error {
+	if s.Subscribe && s.Unsubscribe {
+		return fmt.Errorf("Cannot specify both --subscribe and --unsubscribe")
+	}
+	return nil
+}
+
+type StateOption struct {
+	Close  bool `long:"close" description:"Close"`
+	Reopen bool `long:"reopen" description:"Reopen the closed one"`
+	Lock   bool `long:"lock" description:"Lock the discussion, only the members can comment"`
+	Unlock bool `long:"unlock" description:"Unlock the discussion"`
+	Delete bool `long:"delete" description:"Delete permanently. Ask for the confirmation unless --yes is given"`
+	Yes    bool `long:"yes" description:"Delete without the confirmation, for scripts"`
+}
+
+func (s *StateOption) HasState() bool {
+	return s.Close || s.Reopen || s.Lock || s.Unlock || s.Delete
+}
+
+func (s *StateOption) IsValid() error {
+	if s.Close && s.Reopen {
+		return fmt.Errorf("Cannot specify both --close and --reopen")
+	}
+	if s.Lock && s.Unlock {
+		return fmt.Errorf("Cannot specify both --lock and --unlock")
+	}
+	if s.Delete && (s.Close || s.Reopen || s.Lock || s.Unlock) {
+		return fmt.Errorf("Cannot specify --delete with --close, --reopen, --lock or --unlock")
+	}
+	if s.Yes && !s.Delete {
+		return fmt.Errorf("--yes requires --delete")
+	}
+	return nil
+}
+
+// GetStateEvent returns the state event of the API, that is empty when the state is not changed.
+func (s *StateOption) GetStateEvent() string {
+	if s.Close {
+		return "close"
+	}
+	if s.Reopen {
+		return "reopen"
+	}
+	return ""
+}
+
+// GetDiscussionLocked returns nil when the discussion is not locked nor unlocked.
+func (s *StateOption) GetDiscussionLocked() *bool {
+	if !s.Lock && !s.Unlock {
+		return nil
+	}
+	locked := s.Lock
+	return &locked
+}
@@ -0,0 +1,48 @@
+package internal
+
+import "strings"
+
+// LabelChange is the change of the labels given by a repeatable label option, e.g. --label.
+// "name" replaces the labels, "+name" adds the label and "-name" removes the label.
+type LabelChange struct {
+	UserChange
+}
+
+// ParseLabelChange parses the values of a repeatable label option.
+func ParseLabelChange(values []string) *LabelChange {
+	c := &LabelChange{}
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		switch {
+		case strings.HasPrefix(value, "+"):
+			c.Add = append(c.Add, value[1:])
+		case strings.HasPrefix(value, "-"):
+			c.Remove = append(c.Remove, value[1:])
+		default:
+			c.Replace = append(c.Replace, value)
+		}
+	}
+	return c
+}
+
+// AddTo returns the labels added by the change, e.g. to default_labels on creating.
+// Every label except the removed one is added, because there are no labels to replace.
+func (c *LabelChange) AddTo(labels []string) []string {
+	add := &UserChange{
+		Add:    append(append([]string{}, c.Replace...), c.Add...),
+		Remove: c.Remove,
+	}
+	return add.Apply(labels)
+}
+
+// Values returns the option values of the change, that are parsed to the same change.
+func (c *LabelChange) Values() []string {
+	values := append([]string{}, c.Replace...)
+	for _, name := range c.Add {
+		values = append(values, "+"+name)
+	}
+	for _, name := range c.Remove {
+		values = append(values, "-"+name)
+	}
+	return values
+}
@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLabelChange(t *testing.T) {
+	tests := []struct {
+		name       string
+		values     []string
+		current    []string
+		want       []string
+		wantValues []string
+	}{
+		{
+			name:       "replace",
+			values:     []string{"bug", "@team"},
+			current:    []string{"feature"},
+			want:       []string{"bug", "@team"},
+			wantValues: []string{"bug", "@team"},
+		},
+		{
+			name:       "add and remove",
+			values:     []string{"-feature", "+bug"},
+			current:    []string{"feature", "doing"},
+			want:       []string{"doing", "bug"},
+			wantValues: []string{"+bug", "-feature"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change := ParseLabelChange(tt.values)
+			if got := change.Apply(tt.current); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LabelChange.Apply() = %v, want %v", got, tt.want)
+			}
+			if got := change.Values(); !reflect.DeepEqual(got, tt.wantValues) {
+				t.Errorf("LabelChange.Values() = %v, want %v", got, tt.wantValues)
+			}
+		})
+	}
+}
+
+func TestLabelChange_AddTo(t *testing.T) {
+	change := ParseLabelChange([]string{"bug", "+doing", "-triage"})
+	want := []string{"team", "bug", "doing"}
+	if got := change.AddTo([]string{"team", "triage"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("LabelChange.AddTo() = %v, want %v", got, want)
+	}
+}
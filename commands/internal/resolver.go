@@ -0,0 +1,288 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lighttiger2505/lab/internal/api"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+// Resolver resolves the usernames, milestone titles and label names given by options.
+// The lookups are cached, because a command resolves the same name several times.
+// The clients are taken from the factory only when a name is resolved.
+type Resolver struct {
+	factory    api.APIClientFactory
+	users      map[string]int
+	milestones map[string][]*gitlab.Milestone
+	labels     map[string][]*gitlab.Label
+}
+
+func NewResolver(factory api.APIClientFactory) *Resolver {
+	return &Resolver{
+		factory:    factory,
+		users:      map[string]int{},
+		milestones: map[string][]*gitlab.Milestone{},
+		labels:     map[string][]*gitlab.Label{},
+	}
+}
+
+// UserID returns the ID of the user given by the ID, username or @username.
+func (r *Resolver) UserID(value string) (int, error) {
+	if id, err := strconv.Atoi(value); err == nil {
+		return id, nil
+	}
+	return r.lookupUser(trimUsername(value))
+}
+
+// UserIDs returns the IDs of the users given by the usernames.
+func (r *Resolver) UserIDs(usernames []string) ([]int, error) {
+	ids := []int{}
+	for _, username := range usernames {
+		id, err := r.lookupUser(trimUsername(username))
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// ChangedUserIDs returns the IDs of the users changed from the current usernames by the option values.
+func (r *Resolver) ChangedUserIDs(current []string, values []string) ([]int, error) {
+	return r.UserIDs(ParseUserChange(values).Apply(current))
+}
+
+func (r *Resolver) lookupUser(username string) (int, error) {
+	if id, ok := r.users[username]; ok {
+		return id, nil
+	}
+	users, err := r.factory.GetUserClient().Users(&gitlab.ListUsersOptions{Username: gitlab.String(username)})
+	if err != nil {
+		return 0, err
+	}
+	if len(users) == 0 {
+		return 0, notFoundError("user", username, r.similarUsernames(username))
+	}
+	r.users[username] = users[0].ID
+	return users[0].ID, nil
+}
+
+// similarUsernames searches the users by the head of the username, because a typo is often in the rest.
+func (r *Resolver) similarUsernames(username string) []string {
+	head := username
+	if len(head) > 3 {
+		head = head[:3]
+	}
+	users, err := r.factory.GetUserClient().Users(&gitlab.ListUsersOptions{Search: gitlab.String(head)})
+	if err != nil {
+		return nil
+	}
+	names := []string{}
+	for _, user := range users {
+		names = append(names, user.Username)
+	}
+	return similar(username, names)
+}
+
+// MilestoneID returns the global ID of the milestone given by the title, %IID or global ID.
+// The title is looked up first, because a title can be a number, e.g. "2019".
+func (r *Resolver) MilestoneID(project, value string) (int, error) {
+	milestone, err := r.lookupMilestone(project, value)
+	if err == nil {
+		return milestone.ID, nil
+	}
+	if _, ok := err.(*notFound); !ok {
+		return 0, err
+	}
+	if id, convErr := strconv.Atoi(value); convErr == nil {
+		return id, nil
+	}
+	return 0, err
+}
+
+// MilestoneTitle returns the title of the milestone given by the title or %IID, as written in the project or its groups.
+func (r *Resolver) MilestoneTitle(project, value string) (string, error) {
+	milestone, err := r.lookupMilestone(project, value)
+	if err != nil {
+		return "", err
+	}
+	return milestone.Title, nil
+}
+
+// lookupMilestone finds the milestone by the title, or by the IID written as "%3" like GitLab references.
+// The milestone of the project is preferred to the one of the groups with the same IID.
+func (r *Resolver) lookupMilestone(project, value string) (*gitlab.Milestone, error) {
+	milestones, ok := r.milestones[project]
+	if !ok {
+		var err error
+		milestones, err = r.factory.GetMilestoneClient().ListAllMilestones(project)
+		if err != nil {
+			return nil, err
+		}
+		r.milestones[project] = milestones
+	}
+
+	if strings.HasPrefix(value, "%") {
+		if iid, err := strconv.Atoi(value[1:]); err == nil {
+			var found *gitlab.Milestone
+			for _, milestone := range milestones {
+				if milestone.IID == iid && (found == nil || milestone.ProjectID != 0) {
+					found = milestone
+				}
+			}
+			if found == nil {
+				return nil, notFoundError("milestone", value, nil)
+			}
+			return found, nil
+		}
+	}
+
+	titles := []string{}
+	for _, milestone := range milestones {
+		if strings.EqualFold(milestone.Title, value) {
+			return milestone, nil
+		}
+		titles = append(titles, milestone.Title)
+	}
+	return nil, notFoundError("milestone", value, similar(value, titles))
+}
+
+// LabelNames returns the names of the labels written in the project.
+func (r *Resolver) LabelNames(project string, names []string) ([]string, error) {
+	labels, ok := r.labels[project]
+	if !ok {
+		var err error
+		labels, err = r.factory.GetLabelClient().ListAllLabels(project)
+		if err != nil {
+			return nil, err
+		}
+		r.labels[project] = labels
+	}
+
+	resolved := []string{}
+	for _, name := range names {
+		found := ""
+		all := []string{}
+		for _, label := range labels {
+			if strings.EqualFold(label.Name, name) {
+				found = label.Name
+				break
+			}
+			all = append(all, label.Name)
+		}
+		if found == "" {
+			return nil, notFoundError("label", name, similar(name, all))
+		}
+		resolved = append(resolved, found)
+	}
+	return resolved, nil
+}
+
+// notFound is the error of a name missing in GitLab, told apart from the API errors.
+type notFound struct {
+	kind        string
+	name        string
+	suggestions []string
+}
+
+func (e *notFound) Error() string {
+	if len(e.suggestions) == 0 {
+		return fmt.Sprintf("Not found %s, [%s]", e.kind, e.name)
+	}
+	return fmt.Sprintf("Not found %s, [%s]. Did you mean %s?", e.kind, e.name, strings.Join(e.suggestions, ", "))
+}
+
+// LabelChangeValues returns the values of the repeatable label option with the names written in the project.
+func (r *Resolver) LabelChangeValues(project string, values []string) ([]string, error) {
+	change := ParseLabelChange(values)
+	for _, names := range []*[]string{&change.Replace, &change.Add, &change.Remove} {
+		if len(*names) == 0 {
+			continue
+		}
+		labels, err := r.LabelNames(project, *names)
+		if err != nil {
+			return nil, err
+		}
+		*names = labels
+	}
+	return change.Values(), nil
+}
+
+func notFoundError(kind, name string, suggestions []string) error {
+	return &notFound{kind: kind, name: name, suggestions: suggestions}
+}
+
+// similar returns at most 3 candidates close to the name, the closest first.
+func similar(name string, candidates []string) []string {
+	type scored struct {
+		candidate string
+		distance  int
+	}
+	limit := len(name)/3 + 1
+	if limit < 2 {
+		limit = 2
+	}
+	scores := []scored{}
+	for _, c := range candidates {
+		d := distance(strings.ToLower(name), strings.ToLower(c))
+		if d <= limit {
+			scores = append(scores, scored{candidate: c, distance: d})
+		}
+	}
+	sort.SliceStable(scores, func(i, j int) bool {
+		return scores[i].distance < scores[j].distance
+	})
+
+	results := []string{}
+	for i, s := range scores {
+		if i == 3 {
+			break
+		}
+		results = append(results, s.candidate)
+	}
+	return results
+}
+
+// distance is the Levenshtein distance of the strings.
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur := make([]int, len(rb)+1)
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(rb)]
+}
+
+func min(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+// IsMilestoneWildcard reports whether the milestone filter is a special value of GitLab, not a title.
+func IsMilestoneWildcard(milestone string) bool {
+	switch strings.ToLower(milestone) {
+	case "none", "any", "upcoming", "started":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,175 @@
+package internal
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/lighttiger2505/lab/internal/api"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+func newMockResolver() *Resolver {
+	return NewResolver(&api.MockAPIClientFactory{
+		MockGetUserClient: func() api.User {
+			return &api.MockUserClient{
+				MockUsers: func(opt *gitlab.ListUsersOptions) ([]*gitlab.User, error) {
+					users := []*gitlab.User{
+						&gitlab.User{ID: 1, Username: "alice"},
+						&gitlab.User{ID: 2, Username: "alison"},
+					}
+					if opt.Search != nil {
+						return users, nil
+					}
+					for _, user := range users {
+						if user.Username == *opt.Username {
+							return []*gitlab.User{user}, nil
+						}
+					}
+					return []*gitlab.User{}, nil
+				},
+			}
+		},
+		MockGetMilestoneClient: func() api.Milestone {
+			return &api.MockMilestoneClient{
+				MockListAllMilestones: func(repositoryName string) ([]*gitlab.Milestone, error) {
+					return []*gitlab.Milestone{
+						&gitlab.Milestone{ID: 10, IID: 1, ProjectID: 3, Title: "v1.0"},
+						&gitlab.Milestone{ID: 11, IID: 2, ProjectID: 3, Title: "Sprint 1"},
+						&gitlab.Milestone{ID: 12, IID: 3, ProjectID: 3, Title: "2019"},
+						&gitlab.Milestone{ID: 20, IID: 2, Title: "Group Sprint"},
+					}, nil
+				},
+			}
+		},
+		MockGetLabelClient: func() api.Label {
+			return &api.MockLabelClient{
+				MockListAllLabels: func(repositoryName string) ([]*gitlab.Label, error) {
+					return []*gitlab.Label{
+						&gitlab.Label{Name: "bug"},
+						&gitlab.Label{Name: "Feature"},
+					}, nil
+				},
+			}
+		},
+	})
+}
+
+func TestResolverUserIDs(t *testing.T) {
+	resolver := newMockResolver()
+
+	got, err := resolver.UserIDs([]string{"@alice", "alison"})
+	if err != nil {
+		t.Fatalf("UserIDs() error = %v", err)
+	}
+	if want := []int{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("UserIDs() = %v, want %v", got, want)
+	}
+
+	_, err = resolver.UserIDs([]string{"alcie"})
+	if err == nil {
+		t.Fatalf("UserIDs() want error for unknown user")
+	}
+	if want := "Not found user, [alcie]. Did you mean alice?"; err.Error() != want {
+		t.Errorf("UserIDs() error = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestResolverUserID(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  int
+	}{
+		{name: "id", value: "42", want: 42},
+		{name: "username", value: "alice", want: 1},
+		{name: "at username", value: "@alison", want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newMockResolver().UserID(tt.value)
+			if err != nil {
+				t.Fatalf("UserID() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("UserID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolverMilestone(t *testing.T) {
+	resolver := newMockResolver()
+
+	for value, want := range map[string]int{"sprint 1": 11, "2019": 12, "42": 42, "%2": 11, "group sprint": 20} {
+		id, err := resolver.MilestoneID("group/project", value)
+		if err != nil {
+			t.Fatalf("MilestoneID(%q) error = %v", value, err)
+		}
+		if id != want {
+			t.Errorf("MilestoneID(%q) = %v, want %v", value, id, want)
+		}
+	}
+
+	title, err := resolver.MilestoneTitle("group/project", "V1.0")
+	if err != nil {
+		t.Fatalf("MilestoneTitle() error = %v", err)
+	}
+	if title != "v1.0" {
+		t.Errorf("MilestoneTitle() = %v, want %v", title, "v1.0")
+	}
+
+	_, err = resolver.MilestoneTitle("group/project", "v1.1")
+	if want := "Not found milestone, [v1.1]. Did you mean v1.0?"; err == nil || err.Error() != want {
+		t.Errorf("MilestoneTitle() error = %v, want %q", err, want)
+	}
+
+	_, err = resolver.MilestoneID("group/project", "%9")
+	if want := "Not found milestone, [%9]"; err == nil || err.Error() != want {
+		t.Errorf("MilestoneID() error = %v, want %q", err, want)
+	}
+}
+
+func TestResolverMilestoneAPIError(t *testing.T) {
+	resolver := NewResolver(&api.MockAPIClientFactory{
+		MockGetMilestoneClient: func() api.Milestone {
+			return &api.MockMilestoneClient{
+				MockListAllMilestones: func(repositoryName string) ([]*gitlab.Milestone, error) {
+					return nil, errors.New("Failed list milestone, 403 Forbidden")
+				},
+			}
+		},
+	})
+
+	_, err := resolver.MilestoneID("group/project", "42")
+	if want := "Failed list milestone, 403 Forbidden"; err == nil || err.Error() != want {
+		t.Errorf("MilestoneID() error = %v, want %q", err, want)
+	}
+}
+
+func TestResolverLabelChangeValues(t *testing.T) {
+	got, err := newMockResolver().LabelChangeValues("group/project", []string{"Bug", "+feature", "-BUG"})
+	if err != nil {
+		t.Fatalf("LabelChangeValues() error = %v", err)
+	}
+	if want := []string{"bug", "+Feature", "-bug"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LabelChangeValues() = %v, want %v", got, want)
+	}
+}
+
+func TestResolverLabelNames(t *testing.T) {
+	resolver := newMockResolver()
+
+	got, err := resolver.LabelNames("group/project", []string{"Bug", "feature"})
+	if err != nil {
+		t.Fatalf("LabelNames() error = %v", err)
+	}
+	if want := []string{"bug", "Feature"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LabelNames() = %v, want %v", got, want)
+	}
+
+	_, err = resolver.LabelNames("group/project", []string{"bgu"})
+	if want := "Not found label, [bgu]. Did you mean bug?"; err == nil || err.Error() != want {
+		t.Errorf("LabelNames() error = %v, want %q", err, want)
+	}
+}
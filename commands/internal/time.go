@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/lighttiger2505/lab/internal/api"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+// TimeTrackingMethod tracks the time of an issue or a merge request, after the update by Method if given.
+type TimeTrackingMethod struct {
+	Method  Method
+	Client  api.TimeStats
+	Opt     *TimeTrackingOption
+	Entity  string
+	Project string
+	IID     int
+}
+
+func (m *TimeTrackingMethod) Process() (string, error) {
+	res := ""
+	if m.Method != nil {
+		var err error
+		res, err = m.Method.Process()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if m.Opt.Estimate != "" {
+		if _, err := m.Client.SetTimeEstimate(m.Project, m.Entity, m.IID, m.Opt.Estimate); err != nil {
+			return "", err
+		}
+	}
+	// The reset comes first, so that "--reset-spent --spend 1h" sets the time spent to 1h
+	if m.Opt.ResetSpent {
+		if _, err := m.Client.ResetSpentTime(m.Project, m.Entity, m.IID); err != nil {
+			return "", err
+		}
+	}
+	if m.Opt.Spend != "" {
+		if _, err := m.Client.AddSpentTime(m.Project, m.Entity, m.IID, m.Opt.Spend, m.Opt.Summary); err != nil {
+			return "", err
+		}
+	}
+	return res, nil
+}
+
+// TimeStatsOutput returns the time spent and the estimate of the detail view.
+func TimeStatsOutput(stats *gitlab.TimeStats) string {
+	spent, estimate := "-", "-"
+	if stats != nil && stats.HumanTotalTimeSpent != "" {
+		spent = stats.HumanTotalTimeSpent
+	}
+	if stats != nil && stats.HumanTimeEstimate != "" {
+		estimate = stats.HumanTimeEstimate
+	}
+	return fmt.Sprintf("%s spent / %s estimated", spent, estimate)
+}
@@ -0,0 +1,29 @@
+package internal
+
+import (
+	"github.com/lighttiger2505/lab/internal/api"
+	"github.com/lighttiger2505/lab/internal/gitutil"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+// ValidateToken returns the user of the token and its scopes.
+// The scopes are empty unless the token is a personal access token, or when the GitLab is too old to report them.
+func ValidateToken(factory api.APIClientFactory, domain, token, tokenType string) (*gitlab.User, []string, error) {
+	pInfo := &gitutil.GitLabProjectInfo{Domain: domain}
+	if err := factory.Init(pInfo.ApiUrl(), token, tokenType); err != nil {
+		return nil, nil, err
+	}
+	client := factory.GetUserClient()
+	user, err := client.CurrentUser()
+	if err != nil {
+		return nil, nil, err
+	}
+	if tokenType != api.PrivateToken {
+		return user, []string{}, nil
+	}
+	scopes, err := client.CurrentTokenScopes()
+	if err != nil {
+		scopes = []string{}
+	}
+	return user, scopes, nil
+}
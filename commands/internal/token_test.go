@@ -0,0 +1,51 @@
+package internal
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/lighttiger2505/lab/internal/api"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+func TestValidateToken(t *testing.T) {
+	tests := []struct {
+		name       string
+		tokenType  string
+		err        error
+		wantScopes []string
+		wantErr    bool
+	}{
+		{name: "private token", tokenType: api.PrivateToken, wantScopes: []string{"api"}},
+		{name: "oauth token", tokenType: api.OAuthToken, wantScopes: []string{}},
+		{name: "job token", tokenType: api.JobToken, wantScopes: []string{}},
+		{name: "invalid token", tokenType: api.PrivateToken, err: errors.New("401 Unauthorized"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := &api.MockAPIClientFactory{
+				MockGetUserClient: func() api.User {
+					return &api.MockUserClient{
+						MockCurrentUser: func() (*gitlab.User, error) {
+							if tt.err != nil {
+								return nil, tt.err
+							}
+							return &gitlab.User{Username: "lighttiger2505"}, nil
+						},
+						MockCurrentTokenScopes: func() ([]string, error) {
+							return []string{"api"}, nil
+						},
+					}
+				},
+			}
+			_, scopes, err := ValidateToken(factory, "gitlab.com", "token", tt.tokenType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(scopes, tt.wantScopes) {
+				t.Errorf("ValidateToken() scopes = %v, want %v", scopes, tt.wantScopes)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package internal
+
+import "strings"
+
+// UserChange is the change of the users given by a repeatable username option, e.g. --assignee.
+// "name" replaces the users, "+name" adds the user and "-name" removes the user.
+type UserChange struct {
+	Replace []string
+	Add     []string
+	Remove  []string
+}
+
+// ParseUserChange parses the values of a repeatable username option.
+// The leading "@" of the username is optional.
+func ParseUserChange(values []string) *UserChange {
+	c := &UserChange{}
+	for _, value := range values {
+		switch {
+		case strings.HasPrefix(value, "+"):
+			c.Add = append(c.Add, trimUsername(value[1:]))
+		case strings.HasPrefix(value, "-"):
+			c.Remove = append(c.Remove, trimUsername(value[1:]))
+		default:
+			c.Replace = append(c.Replace, trimUsername(value))
+		}
+	}
+	return c
+}
+
+func trimUsername(value string) string {
+	return strings.TrimPrefix(strings.TrimSpace(value), "@")
+}
+
+func (c *UserChange) IsEmpty() bool {
+	return len(c.Replace) == 0 && len(c.Add) == 0 && len(c.Remove) == 0
+}
+
+// Apply returns the usernames changed from the current usernames.
+func (c *UserChange) Apply(current []string) []string {
+	base := current
+	if len(c.Replace) > 0 {
+		base = c.Replace
+	}
+
+	removed := map[string]bool{}
+	for _, name := range c.Remove {
+		removed[name] = true
+	}
+	seen := map[string]bool{}
+	usernames := []string{}
+	for _, name := range append(append([]string{}, base...), c.Add...) {
+		if name == "" || removed[name] || seen[name] {
+			continue
+		}
+		seen[name] = true
+		usernames = append(usernames, name)
+	}
+	return usernames
+}
+
+// UpdateAssigneeIDs returns the assignee IDs for the update option.
+// GitLab unassigns all users by the ID 0, because an empty list is omitted.
+func UpdateAssigneeIDs(ids []int) []int {
+	if len(ids) == 0 {
+		return []int{0}
+	}
+	return ids
+}
@@ -0,0 +1,47 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUserChange_Apply(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		current []string
+		want    []string
+	}{
+		{
+			name:    "replace",
+			values:  []string{"@alice", "bob"},
+			current: []string{"carol"},
+			want:    []string{"alice", "bob"},
+		},
+		{
+			name:    "add and remove",
+			values:  []string{"+alice", "-carol", "+dave"},
+			current: []string{"carol", "dave"},
+			want:    []string{"dave", "alice"},
+		},
+		{
+			name:    "replace and remove",
+			values:  []string{"alice", "bob", "-bob"},
+			current: []string{"carol"},
+			want:    []string{"alice"},
+		},
+		{
+			name:    "remove all",
+			values:  []string{"-carol"},
+			current: []string{"carol"},
+			want:    []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseUserChange(tt.values).Apply(tt.current); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UserChange.Apply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
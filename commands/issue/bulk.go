@@ -0,0 +1,129 @@
+package issue
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/lighttiger2505/lab/commands/internal"
+	"github.com/lighttiger2505/lab/internal/api"
+	"github.com/ryanuber/columnize"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+const bulkPerPage = 100
+
+// bulkMethod applies the changes to every issue matching the list options.
+// It only prints the issues to change unless --apply is given.
+type bulkMethod struct {
+	client   api.Issue
+	resolver *internal.Resolver
+	listOpt  *ListOption
+	opt      *CreateUpdateOption
+	stateOpt *internal.StateOption
+	bulkOpt  *BulkOption
+	project  string
+}
+
+func (m *bulkMethod) Process() (string, error) {
+	issues, err := m.listIssues()
+	if err != nil {
+		return "", err
+	}
+	if len(issues) == 0 {
+		return "No issue matches", nil
+	}
+
+	// The options are made before the workers start, because the resolver is not safe for concurrent use
+	updates := make([]*gitlab.UpdateIssueOptions, len(issues))
+	for i, issue := range issues {
+		updateOpt := makeBulkUpdateOption(m.opt, m.stateOpt)
+		if err := setUpdateAssignees(updateOpt, m.opt, m.resolver, issue); err != nil {
+			return "", err
+		}
+		setUpdateLabels(updateOpt, m.opt, issue)
+		updates[i] = updateOpt
+	}
+
+	if !m.bulkOpt.Apply {
+		preview := columnize.SimpleFormat(listOutput(issues))
+		if replaced := internal.ParseLabelChange(m.opt.Labels).Replace; len(replaced) > 0 {
+			preview = fmt.Sprintf("%s\n\nWarning: the labels of the issues will be replaced by %s. Use --label=+name to add a label",
+				preview, strings.Join(replaced, ", "))
+		}
+		return fmt.Sprintf("%s\n\n%d issues of the state \"%s\" will be updated. Run again with --apply to update them",
+			preview, len(issues), m.listOpt.getState()), nil
+	}
+
+	errs := m.updateIssues(issues, updates)
+	failures := []string{}
+	for i, err := range errs {
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("#%d %s", issues[i].IID, err))
+		}
+	}
+	if len(failures) > 0 {
+		return "", fmt.Errorf("Failed update %d of %d issues\n%s", len(failures), len(issues), strings.Join(failures, "\n"))
+	}
+	return fmt.Sprintf("Updated %d issues", len(issues)), nil
+}
+
+// listIssues lists every issue matching the list options, not limited by --num.
+func (m *bulkMethod) listIssues() ([]*gitlab.Issue, error) {
+	listOpt := makeProjectIssueOption(m.listOpt)
+	listOpt.PerPage = bulkPerPage
+	results := []*gitlab.Issue{}
+	for page := 1; ; page++ {
+		listOpt.Page = page
+		issues, err := m.client.GetProjectIssues(listOpt, m.project)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, issues...)
+		if len(issues) < bulkPerPage {
+			return results, nil
+		}
+	}
+}
+
+// updateIssues updates the issues by the bounded workers, and returns the errors in the order of the issues.
+func (m *bulkMethod) updateIssues(issues []*gitlab.Issue, updates []*gitlab.UpdateIssueOptions) []error {
+	errs := make([]error, len(issues))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < m.bulkOpt.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				_, errs[i] = m.client.UpdateIssue(updates[i], issues[i].IID, m.project)
+			}
+		}()
+	}
+	for i := range issues {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return errs
+}
+
+// makeBulkUpdateOption makes the changes of the issues, that leaves the title and description as they are.
+func makeBulkUpdateOption(opt *CreateUpdateOption, stateOpt *internal.StateOption) *gitlab.UpdateIssueOptions {
+	updateOpt := &gitlab.UpdateIssueOptions{
+		DiscussionLocked: stateOpt.GetDiscussionLocked(),
+	}
+	if stateEvent := stateOpt.GetStateEvent(); stateEvent != "" {
+		updateOpt.StateEvent = gitlab.String(stateEvent)
+	}
+	if opt.StateEvent != "" {
+		updateOpt.StateEvent = gitlab.String(opt.StateEvent)
+	}
+	if opt.AssigneeID != 0 {
+		updateOpt.AssigneeIDs = []int{opt.AssigneeID}
+	}
+	if opt.MilestoneID != 0 {
+		updateOpt.MilestoneID = gitlab.Int(opt.MilestoneID)
+	}
+	return updateOpt
+}
@@ -0,0 +1,178 @@
+package issue
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lighttiger2505/lab/commands/internal"
+	"github.com/lighttiger2505/lab/internal/api"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+func newBulkIssues(n int) []*gitlab.Issue {
+	issues := []*gitlab.Issue{}
+	for i := 1; i <= n; i++ {
+		issues = append(issues, &gitlab.Issue{IID: i, Title: fmt.Sprintf("issue%d", i)})
+	}
+	return issues
+}
+
+func Test_bulkMethod_Process(t *testing.T) {
+	// Two pages of the issues
+	issues := newBulkIssues(bulkPerPage + 2)
+	listIssues := func(opt *gitlab.ListProjectIssuesOptions, repositoryName string) ([]*gitlab.Issue, error) {
+		if *opt.Search != "crash" {
+			t.Errorf("invalid search, %s", *opt.Search)
+		}
+		start := (opt.Page - 1) * opt.PerPage
+		end := start + opt.PerPage
+		if end > len(issues) {
+			end = len(issues)
+		}
+		return issues[start:end], nil
+	}
+
+	tests := []struct {
+		name       string
+		apply      bool
+		failIID    int
+		want       string
+		wantErr    bool
+		wantUpdate int
+	}{
+		{
+			name: "dry-run",
+			want: "102 issues of the state \"opened\" will be updated. Run again with --apply to update them",
+		},
+		{
+			name:       "apply",
+			apply:      true,
+			want:       "Updated 102 issues",
+			wantUpdate: len(issues),
+		},
+		{
+			name:       "failure",
+			apply:      true,
+			failIID:    5,
+			wantErr:    true,
+			wantUpdate: len(issues),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var l sync.Mutex
+			updated, running, maxRunning := 0, 0, 0
+			client := &api.MockLabIssueClient{
+				MockGetProjectIssues: listIssues,
+				MockUpdateIssue: func(opt *gitlab.UpdateIssueOptions, pid int, repositoryName string) (*gitlab.Issue, error) {
+					l.Lock()
+					updated++
+					running++
+					if running > maxRunning {
+						maxRunning = running
+					}
+					l.Unlock()
+
+					time.Sleep(time.Millisecond)
+					if opt.Title != nil || *opt.StateEvent != "close" || len(*opt.Labels) != 1 || (*opt.Labels)[0] != "bug" {
+						t.Errorf("invalid update option, %v", opt)
+					}
+
+					l.Lock()
+					running--
+					l.Unlock()
+					if pid == tt.failIID {
+						return nil, fmt.Errorf("Failed update issue. 403 Forbidden")
+					}
+					return &gitlab.Issue{}, nil
+				},
+			}
+			m := &bulkMethod{
+				client:   client,
+				listOpt:  &ListOption{Search: "crash", State: "opened"},
+				opt:      &CreateUpdateOption{Labels: []string{"bug"}},
+				stateOpt: &internal.StateOption{Close: true},
+				bulkOpt:  &BulkOption{Apply: tt.apply, Workers: 3},
+				project:  "group/project",
+			}
+			got, err := m.Process()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Process() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !strings.Contains(err.Error(), "Failed update 1 of 102 issues\n#5 ") {
+				t.Errorf("invalid error, %v", err)
+			}
+			if !strings.HasSuffix(got, tt.want) {
+				t.Errorf("Process() = %q, want suffix %q", got, tt.want)
+			}
+			if !tt.apply && !tt.wantErr && !strings.Contains(got, "Warning: the labels of the issues will be replaced by bug.") {
+				t.Errorf("Process() = %q, want the warning of the replaced labels", got)
+			}
+			if updated != tt.wantUpdate {
+				t.Errorf("updated %d issues, want %d", updated, tt.wantUpdate)
+			}
+			if maxRunning > 3 {
+				t.Errorf("%d updates ran at once over the workers", maxRunning)
+			}
+		})
+	}
+}
+
+func Test_setBulkState(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "default", args: []string{"--bulk", "--close"}, want: "opened"},
+		{name: "state", args: []string{"--bulk", "--label", "bug", "--state", "all"}, want: "all"},
+		{name: "closed", args: []string{"--bulk", "--reopen", "-C"}, want: "closed"},
+		{name: "without bulk", args: []string{}, want: "all"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opt Option
+			parser := newOptionParser(&opt)
+			if _, err := parser.ParseArgs(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			setBulkState(opt, parser)
+			if got := opt.ListOption.getState(); got != tt.want {
+				t.Errorf("setBulkState() state = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_validBulkOption(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		iid     int
+		wantErr bool
+	}{
+		{name: "labels", args: []string{"--bulk", "--label", "bug", "-s", "crash"}},
+		{name: "close", args: []string{"--bulk", "--close", "--apply"}},
+		{name: "without changes", args: []string{"--bulk", "-s", "crash"}, wantErr: true},
+		{name: "with issue id", args: []string{"--bulk", "--close"}, iid: 12, wantErr: true},
+		{name: "with group", args: []string{"--bulk", "--close", "--group", "group"}, wantErr: true},
+		{name: "with title", args: []string{"--bulk", "-i", "title"}, wantErr: true},
+		{name: "with delete", args: []string{"--bulk", "--delete"}, wantErr: true},
+		{name: "no workers", args: []string{"--bulk", "--close", "--workers", "0"}, wantErr: true},
+		{name: "apply without bulk", args: []string{"--apply"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opt Option
+			if _, err := newOptionParser(&opt).ParseArgs(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			if err := validBulkOption(opt, tt.iid); (err != nil) != tt.wantErr {
+				t.Errorf("validBulkOption() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
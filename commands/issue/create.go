@@ -21,28 +21,46 @@ func makeCreateIssueOptions(opt *CreateUpdateOption, title, description string,
 	if opt.MilestoneID != 0 {
 		createIssueOption.MilestoneID = gitlab.Int(opt.MilestoneID)
 	}
+	if labels := opt.getCreateLabels(pInfo.Profile.DefaultLabels); len(labels) > 0 {
+		gitlabLabels := gitlab.Labels(labels)
+		createIssueOption.Labels = &gitlabLabels
+	}
 	return createIssueOption
 }
 
-func makeIssueTemplateOption() *gitlab.GetRawFileOptions {
+// setCreateAssignees overrides the assignee of the option and config by --assignee.
+func setCreateAssignees(createOpt *gitlab.CreateIssueOptions, opt *CreateUpdateOption, resolver *internal.Resolver) error {
+	ids, err := opt.getAssigneeIDs(resolver, nil)
+	if err != nil {
+		return err
+	}
+	if ids != nil {
+		createOpt.AssigneeIDs = ids
+	}
+	return nil
+}
+
+func makeIssueTemplateOption(ref string) *gitlab.GetRawFileOptions {
 	opt := &gitlab.GetRawFileOptions{
-		Ref: gitlab.String("master"),
+		Ref: gitlab.String(ref),
 	}
 	return opt
 }
 
 type createMethod struct {
-	client  api.Issue
-	opt     *CreateUpdateOption
-	project string
-	pInfo   *gitutil.GitLabProjectInfo
+	client   api.Issue
+	resolver *internal.Resolver
+	opt      *CreateUpdateOption
+	project  string
+	pInfo    *gitutil.GitLabProjectInfo
 }
 
 func (m *createMethod) Process() (string, error) {
-	issue, err := m.client.CreateIssue(
-		makeCreateIssueOptions(m.opt, m.opt.Title, m.opt.Message, m.pInfo),
-		m.pInfo.Project,
-	)
+	createOpt := makeCreateIssueOptions(m.opt, m.opt.Title, m.opt.Message, m.pInfo)
+	if err := setCreateAssignees(createOpt, m.opt, m.resolver); err != nil {
+		return "", err
+	}
+	issue, err := m.client.CreateIssue(createOpt, m.pInfo.Project)
 	if err != nil {
 		return "", err
 	}
@@ -52,6 +70,8 @@ func (m *createMethod) Process() (string, error) {
 type createOnEditorMethod struct {
 	issueClient      api.Issue
 	repositoryClient api.Repository
+	resolver         *internal.Resolver
+	projectClient    api.Project
 	opt              *CreateUpdateOption
 	editFunc         func(program, file string) error
 	pInfo            *gitutil.GitLabProjectInfo
@@ -60,14 +80,18 @@ type createOnEditorMethod struct {
 const templateDir = ".gitlab/issue_templates"
 
 func (m *createOnEditorMethod) Process() (string, error) {
-	templateFilename := m.opt.Template
+	templateFilename := m.opt.getTemplate(m.pInfo.Profile)
 	var template string
 	if templateFilename != "" {
+		ref, err := m.projectClient.DefaultBranch(m.pInfo.Project)
+		if err != nil {
+			return "", err
+		}
 		filename := templateDir + "/" + templateFilename
 		res, err := m.repositoryClient.GetFile(
 			m.pInfo.Project,
 			filename,
-			makeIssueTemplateOption(),
+			makeIssueTemplateOption(ref),
 		)
 		if err != nil {
 			return "", err
@@ -90,10 +114,11 @@ func (m *createOnEditorMethod) Process() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	issue, err := m.issueClient.CreateIssue(
-		makeCreateIssueOptions(m.opt, title, message, m.pInfo),
-		m.pInfo.Project,
-	)
+	createOpt := makeCreateIssueOptions(m.opt, title, message, m.pInfo)
+	if err := setCreateAssignees(createOpt, m.opt, m.resolver); err != nil {
+		return "", err
+	}
+	issue, err := m.issueClient.CreateIssue(createOpt, m.pInfo.Project)
 	if err != nil {
 		return "", err
 	}
@@ -94,6 +94,7 @@ func Test_createOnEditorMethod_Process(t *testing.T) {
 	type fields struct {
 		issueClient      api.Issue
 		repositoryClient api.Repository
+		projectClient    api.Project
 		opt              *CreateUpdateOption
 		editFunc         func(program, file string) error
 		pInfo            *gitutil.GitLabProjectInfo
@@ -159,9 +160,17 @@ func Test_createOnEditorMethod_Process(t *testing.T) {
 				},
 				repositoryClient: &api.MockRepositoryClient{
 					MockGetFile: func(repositoryName string, filename string, opt *gitlab.GetRawFileOptions) (string, error) {
+						if got, want := *opt.Ref, "develop"; got != want {
+							t.Errorf("invalid template ref, got %s, want %s", got, want)
+						}
 						return "template", nil
 					},
 				},
+				projectClient: &api.MockProjectClient{
+					MockDefaultBranch: func(project string) (string, error) {
+						return "develop", nil
+					},
+				},
 				opt: &CreateUpdateOption{
 					Title:      "title",
 					Message:    "desc",
@@ -183,6 +192,7 @@ func Test_createOnEditorMethod_Process(t *testing.T) {
 			m := &createOnEditorMethod{
 				issueClient:      tt.fields.issueClient,
 				repositoryClient: tt.fields.repositoryClient,
+				projectClient:    tt.fields.projectClient,
 				opt:              tt.fields.opt,
 				editFunc:         tt.fields.editFunc,
 				pInfo:            tt.fields.pInfo,
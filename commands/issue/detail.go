@@ -13,6 +13,7 @@ import (
 type detailMethod struct {
 	issueClient api.Issue
 	noteClient  api.Note
+	linkClient  api.IssueLink
 	id          int
 	project     string
 	opt         *ShowOption
@@ -25,6 +26,10 @@ func (m *detailMethod) Process() (string, error) {
 	}
 	res := issueDetailOutput(issue)
 
+	if relations := m.relationOutput(issue); relations != "" {
+		res = strings.Join([]string{res, relations}, "\n")
+	}
+
 	if m.opt.NoComment {
 		return res, nil
 	}
@@ -47,6 +52,76 @@ func (m *detailMethod) Process() (string, error) {
 	return res, nil
 }
 
+// relationOutput lists the linked issues, the merge requests that will close the issue and the other related merge requests.
+// The relations are only a supplement of the issue, so the failure to get them is a warning instead of an error,
+// e.g. the issue links are not available in the GitLab.
+func (m *detailMethod) relationOutput(issue *gitlab.Issue) string {
+	warnings := []string{}
+	linkedIssues, err := m.linkClient.ListIssueLinks(m.project, m.id)
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("Warning: cannot get the linked issues. %s", err))
+	}
+	closedBy, err := m.issueClient.GetClosedByMergeRequests(m.id, m.project)
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("Warning: cannot get the merge requests closing the issue. %s", err))
+	}
+	related, err := m.issueClient.GetRelatedMergeRequests(m.id, m.project)
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("Warning: cannot get the related merge requests. %s", err))
+	}
+
+	closing := map[int]bool{}
+	for _, mr := range closedBy {
+		closing[mr.ID] = true
+	}
+
+	outputs := []string{}
+	if len(linkedIssues) > 0 {
+		outputs = append(outputs, "", "Linked issues:")
+		for _, linked := range linkedIssues {
+			outputs = append(outputs, fmt.Sprintf("  %s %s %s [%s]",
+				strings.Replace(linked.LinkType, "_", " ", -1),
+				issueReference(issue, &linked.Issue),
+				linked.Title,
+				linked.State,
+			))
+		}
+	}
+	if len(closedBy) > 0 {
+		outputs = append(outputs, "", "Closed by:")
+		for _, mr := range closedBy {
+			outputs = append(outputs, mergeRequestRelationOutput(mr))
+		}
+	}
+	relatedOutputs := []string{}
+	for _, mr := range related {
+		if !closing[mr.ID] {
+			relatedOutputs = append(relatedOutputs, mergeRequestRelationOutput(mr))
+		}
+	}
+	if len(relatedOutputs) > 0 {
+		outputs = append(outputs, "", "Related merge requests:")
+		outputs = append(outputs, relatedOutputs...)
+	}
+	if len(warnings) > 0 {
+		outputs = append(outputs, "")
+		outputs = append(outputs, warnings...)
+	}
+	return strings.Join(outputs, "\n")
+}
+
+// issueReference returns the URL of the linked issue in another project.
+func issueReference(issue, linked *gitlab.Issue) string {
+	if linked.ProjectID != issue.ProjectID && linked.WebURL != "" {
+		return linked.WebURL
+	}
+	return fmt.Sprintf("#%d", linked.IID)
+}
+
+func mergeRequestRelationOutput(mr *gitlab.MergeRequest) string {
+	return fmt.Sprintf("  !%d %s [%s]", mr.IID, mr.Title, mr.State)
+}
+
 func makeListIssueNotesOptions() *gitlab.ListIssueNotesOptions {
 	lopt := gitlab.ListOptions{
 		Page:    1,
@@ -60,9 +135,10 @@ func makeListIssueNotesOptions() *gitlab.ListIssueNotesOptions {
 
 func issueDetailOutput(issue *gitlab.Issue) string {
 	base := `%s %s [%s] (created by @%s, %s)
-Assignee: %s
+Assignees: %s
 Milestone: %s
 Labels: %s
+Time: %s
 
 %s`
 
@@ -86,14 +162,28 @@ Labels: %s
 		stateColor(issue.State),
 		issue.Author.Name,
 		issue.CreatedAt.String(),
-		issue.Assignee.Name,
+		strings.Join(assigneeNames(issue), ", "),
 		milestone,
 		strings.Join(issue.Labels, ", "),
+		internal.TimeStatsOutput(issue.TimeStats),
 		internal.SweepMarkdownComment(issue.Description),
 	)
 	return detial
 }
 
+// assigneeNames returns the names of every assignee.
+// The old GitLab without multiple assignees returns only the assignee.
+func assigneeNames(issue *gitlab.Issue) []string {
+	names := []string{}
+	for _, assignee := range issue.Assignees {
+		names = append(names, assignee.Name)
+	}
+	if len(names) == 0 && issue.Assignee != nil {
+		names = append(names, issue.Assignee.Name)
+	}
+	return names
+}
+
 func noteOutput(note *gitlab.Note) string {
 	base := `
 %s (created by @%s, %s)
@@ -1,6 +1,7 @@
 package issue
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -24,6 +25,10 @@ func Test_detailMethod_Process(t *testing.T) {
 		CreatedAt:   &createdAt,
 		UpdatedAt:   &updatedAt,
 		Description: "Description",
+		TimeStats: &gitlab.TimeStats{
+			HumanTimeEstimate:   "3h",
+			HumanTotalTimeSpent: "1h 30m",
+		},
 	}
 	notes := []*gitlab.Note{
 		&gitlab.Note{
@@ -64,10 +69,20 @@ func Test_detailMethod_Process(t *testing.T) {
 		},
 	}
 
+	noLinkClient := &api.MockIssueLinkClient{
+		MockListIssueLinks: func(project string, iid int) ([]*api.LinkedIssue, error) {
+			return []*api.LinkedIssue{}, nil
+		},
+	}
+	noMergeRequests := func(pid int, repositoryName string) ([]*gitlab.MergeRequest, error) {
+		return []*gitlab.MergeRequest{}, nil
+	}
+
 	// Define sub tests
 	type fields struct {
 		issueClient api.Issue
 		noteClient  api.Note
+		linkClient  api.IssueLink
 		id          int
 		project     string
 		opt         *ShowOption
@@ -85,22 +100,26 @@ func Test_detailMethod_Process(t *testing.T) {
 					MockGetIssue: func(pid int, repositoryName string) (*gitlab.Issue, error) {
 						return issue, nil
 					},
+					MockGetClosedByMergeRequests: noMergeRequests,
+					MockGetRelatedMergeRequests:  noMergeRequests,
 				},
 				noteClient: &api.MockNoteClient{
 					MockGetIssueNotes: func(repositoryName string, iid int, opt *gitlab.ListIssueNotesOptions) ([]*gitlab.Note, error) {
 						return notes, nil
 					},
 				},
-				project: "group/project",
-				id:      12,
+				linkClient: noLinkClient,
+				project:    "group/project",
+				id:         12,
 				opt: &ShowOption{
 					NoComment: true,
 				},
 			},
 			want: `12 Title12 [State12] (created by @AuthorName, 2018-02-14 00:00:00 +0000 UTC)
-Assignee: AssigneeName
+Assignees: AssigneeName
 Milestone: 
 Labels: 
+Time: 1h 30m spent / 3h estimated
 
 Description`,
 			wantErr: false,
@@ -112,22 +131,26 @@ Description`,
 					MockGetIssue: func(pid int, repositoryName string) (*gitlab.Issue, error) {
 						return issue, nil
 					},
+					MockGetClosedByMergeRequests: noMergeRequests,
+					MockGetRelatedMergeRequests:  noMergeRequests,
 				},
 				noteClient: &api.MockNoteClient{
 					MockGetIssueNotes: func(repositoryName string, iid int, opt *gitlab.ListIssueNotesOptions) ([]*gitlab.Note, error) {
 						return notes, nil
 					},
 				},
-				project: "group/project",
-				id:      12,
+				linkClient: noLinkClient,
+				project:    "group/project",
+				id:         12,
 				opt: &ShowOption{
 					NoComment: false,
 				},
 			},
 			want: `12 Title12 [State12] (created by @AuthorName, 2018-02-14 00:00:00 +0000 UTC)
-Assignee: AssigneeName
+Assignees: AssigneeName
 Milestone: 
 Labels: 
+Time: 1h 30m spent / 3h estimated
 
 Description
 
@@ -140,6 +163,99 @@ comment 2 (created by @author2, 2018-02-14 00:00:00 +0000 UTC)
 body`,
 			wantErr: false,
 		},
+		{
+			name: "show issue with links and merge requests",
+			fields: fields{
+				issueClient: &api.MockLabIssueClient{
+					MockGetIssue: func(pid int, repositoryName string) (*gitlab.Issue, error) {
+						return issue, nil
+					},
+					MockGetClosedByMergeRequests: func(pid int, repositoryName string) ([]*gitlab.MergeRequest, error) {
+						return []*gitlab.MergeRequest{
+							&gitlab.MergeRequest{ID: 100, IID: 3, Title: "Fix", State: "opened"},
+						}, nil
+					},
+					MockGetRelatedMergeRequests: func(pid int, repositoryName string) ([]*gitlab.MergeRequest, error) {
+						return []*gitlab.MergeRequest{
+							&gitlab.MergeRequest{ID: 100, IID: 3, Title: "Fix", State: "opened"},
+							&gitlab.MergeRequest{ID: 101, IID: 4, Title: "Refactor", State: "merged"},
+						}, nil
+					},
+				},
+				linkClient: &api.MockIssueLinkClient{
+					MockListIssueLinks: func(project string, iid int) ([]*api.LinkedIssue, error) {
+						return []*api.LinkedIssue{
+							&api.LinkedIssue{
+								Issue:    gitlab.Issue{IID: 13, Title: "Title13", State: "opened"},
+								LinkType: api.LinkTypeIsBlockedBy,
+							},
+							&api.LinkedIssue{
+								Issue:    gitlab.Issue{IID: 1, ProjectID: 2, Title: "Other", State: "closed", WebURL: "https://gitlab.com/group/other/issues/1"},
+								LinkType: api.LinkTypeRelatesTo,
+							},
+						}, nil
+					},
+				},
+				project: "group/project",
+				id:      12,
+				opt: &ShowOption{
+					NoComment: true,
+				},
+			},
+			want: `12 Title12 [State12] (created by @AuthorName, 2018-02-14 00:00:00 +0000 UTC)
+Assignees: AssigneeName
+Milestone: 
+Labels: 
+Time: 1h 30m spent / 3h estimated
+
+Description
+
+Linked issues:
+  is blocked by #13 Title13 [opened]
+  relates to https://gitlab.com/group/other/issues/1 Other [closed]
+
+Closed by:
+  !3 Fix [opened]
+
+Related merge requests:
+  !4 Refactor [merged]`,
+			wantErr: false,
+		},
+		{
+			name: "show issue without the relations on error",
+			fields: fields{
+				issueClient: &api.MockLabIssueClient{
+					MockGetIssue: func(pid int, repositoryName string) (*gitlab.Issue, error) {
+						return issue, nil
+					},
+					MockGetClosedByMergeRequests: noMergeRequests,
+					MockGetRelatedMergeRequests: func(pid int, repositoryName string) ([]*gitlab.MergeRequest, error) {
+						return nil, fmt.Errorf("Failed get related merge requests. 403 Forbidden")
+					},
+				},
+				linkClient: &api.MockIssueLinkClient{
+					MockListIssueLinks: func(project string, iid int) ([]*api.LinkedIssue, error) {
+						return nil, fmt.Errorf("Failed list issue links. 404 Not Found")
+					},
+				},
+				project: "group/project",
+				id:      12,
+				opt: &ShowOption{
+					NoComment: true,
+				},
+			},
+			want: `12 Title12 [State12] (created by @AuthorName, 2018-02-14 00:00:00 +0000 UTC)
+Assignees: AssigneeName
+Milestone: 
+Labels: 
+Time: 1h 30m spent / 3h estimated
+
+Description
+
+Warning: cannot get the linked issues. Failed list issue links. 404 Not Found
+Warning: cannot get the related merge requests. Failed get related merge requests. 403 Forbidden`,
+			wantErr: false,
+		},
 	}
 
 	// Do tests
@@ -148,6 +264,7 @@ body`,
 			m := &detailMethod{
 				issueClient: tt.fields.issueClient,
 				noteClient:  tt.fields.noteClient,
+				linkClient:  tt.fields.linkClient,
 				id:          tt.fields.id,
 				project:     tt.fields.project,
 				opt:         tt.fields.opt,
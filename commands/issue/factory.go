@@ -25,27 +25,63 @@ func (c *IssueMethodFactory) CreateMethod(opt Option, pInfo *gitutil.GitLabProje
 		}
 	}
 
+	resolver := internal.NewResolver(factory)
+
 	if iid > 0 {
-		if opt.CreateUpdateOption.hasEdit() {
-			return &updateOnEditorMethod{
-				client:   factory.GetIssueClient(),
-				opt:      opt.CreateUpdateOption,
-				project:  pInfo.Project,
-				id:       iid,
-				editFunc: nil,
+		if opt.StateOption.Delete {
+			return &deleteMethod{
+				client:  factory.GetIssueClient(),
+				project: pInfo.Project,
+				id:      iid,
+			}
+		}
+
+		method := newUpdateMethod(opt, pInfo, iid, factory, resolver)
+		if opt.StateOption.HasState() {
+			method = &stateMethod{
+				method:  method,
+				client:  factory.GetIssueClient(),
+				opt:     opt.StateOption,
+				project: pInfo.Project,
+				id:      iid,
+			}
+		}
+		if opt.TimeTrackingOption.HasTimeTracking() {
+			method = &internal.TimeTrackingMethod{
+				Method:  method,
+				Client:  factory.GetTimeStatsClient(),
+				Opt:     opt.TimeTrackingOption,
+				Entity:  api.IssueEntity,
+				Project: pInfo.Project,
+				IID:     iid,
+			}
+		}
+		if opt.LinkOption.hasLink() {
+			method = &linkMethod{
+				method:      method,
+				client:      factory.GetIssueLinkClient(),
+				issueClient: factory.GetIssueClient(),
+				opt:         opt.LinkOption,
+				project:     pInfo.Project,
+				id:          iid,
 			}
 		}
-		if opt.CreateUpdateOption.hasUpdate() {
-			return &updateMethod{
+		if opt.SubscriptionOption.HasSubscription() {
+			method = &subscribeMethod{
+				method:  method,
 				client:  factory.GetIssueClient(),
-				opt:     opt.CreateUpdateOption,
+				opt:     opt.SubscriptionOption,
 				project: pInfo.Project,
 				id:      iid,
 			}
 		}
+		if method != nil {
+			return method
+		}
 		return &detailMethod{
 			issueClient: factory.GetIssueClient(),
 			noteClient:  factory.GetNoteClient(),
+			linkClient:  factory.GetIssueLinkClient(),
 			opt:         opt.ShowOption,
 			project:     pInfo.Project,
 			id:          iid,
@@ -53,10 +89,23 @@ func (c *IssueMethodFactory) CreateMethod(opt Option, pInfo *gitutil.GitLabProje
 	}
 
 	// Case of nothing Issue id
+	if opt.BulkOption.Bulk {
+		return &bulkMethod{
+			client:   factory.GetIssueClient(),
+			resolver: resolver,
+			listOpt:  opt.ListOption,
+			opt:      opt.CreateUpdateOption,
+			stateOpt: opt.StateOption,
+			bulkOpt:  opt.BulkOption,
+			project:  pInfo.Project,
+		}
+	}
 	if opt.CreateUpdateOption.hasEdit() {
 		return &createOnEditorMethod{
 			issueClient:      factory.GetIssueClient(),
 			repositoryClient: factory.GetRepositoryClient(),
+			resolver:         resolver,
+			projectClient:    factory.GetProjectClient(),
 			opt:              opt.CreateUpdateOption,
 			pInfo:            pInfo,
 			editFunc:         nil,
@@ -64,9 +113,16 @@ func (c *IssueMethodFactory) CreateMethod(opt Option, pInfo *gitutil.GitLabProje
 	}
 	if opt.CreateUpdateOption.hasCreate() {
 		return &createMethod{
+			client:   factory.GetIssueClient(),
+			resolver: resolver,
+			opt:      opt.CreateUpdateOption,
+			pInfo:    pInfo,
+		}
+	}
+	if opt.ListOption.Group != "" {
+		return &listGroupMethod{
 			client: factory.GetIssueClient(),
-			opt:    opt.CreateUpdateOption,
-			pInfo:  pInfo,
+			opt:    opt.ListOption,
 		}
 	}
 	if opt.ListOption.AllProject {
@@ -83,6 +139,30 @@ func (c *IssueMethodFactory) CreateMethod(opt Option, pInfo *gitutil.GitLabProje
 	}
 }
 
+// newUpdateMethod returns nil when the issue is not updated.
+func newUpdateMethod(opt Option, pInfo *gitutil.GitLabProjectInfo, iid int, factory api.APIClientFactory, resolver *internal.Resolver) internal.Method {
+	if opt.CreateUpdateOption.hasEdit() {
+		return &updateOnEditorMethod{
+			client:   factory.GetIssueClient(),
+			resolver: resolver,
+			opt:      opt.CreateUpdateOption,
+			project:  pInfo.Project,
+			id:       iid,
+			editFunc: nil,
+		}
+	}
+	if opt.CreateUpdateOption.hasUpdate() {
+		return &updateMethod{
+			client:   factory.GetIssueClient(),
+			resolver: resolver,
+			opt:      opt.CreateUpdateOption,
+			project:  pInfo.Project,
+			id:       iid,
+		}
+	}
+	return nil
+}
+
 type MockMethodFactory struct{}
 
 func (c *MockMethodFactory) CreateMethod(opt Option, pInfo *gitutil.GitLabProjectInfo, iid int, factory api.APIClientFactory) internal.Method {
@@ -1,9 +1,13 @@
 package issue
 
 import (
+	"fmt"
+
 	flags "github.com/jessevdk/go-flags"
 	"github.com/lighttiger2505/lab/commands/internal"
+	"github.com/lighttiger2505/lab/internal/api"
 	"github.com/lighttiger2505/lab/internal/config"
+	gitlab "github.com/xanzy/go-gitlab"
 )
 
 type Option struct {
@@ -11,17 +15,27 @@ type Option struct {
 	CreateUpdateOption   *CreateUpdateOption            `group:"Create, Update Options"`
 	ListOption           *ListOption                    `group:"List Options"`
 	ShowOption           *ShowOption                    `group:"Show Options"`
+	StateOption          *internal.StateOption          `group:"State Options"`
+	TimeTrackingOption   *internal.TimeTrackingOption   `group:"Time Tracking Options"`
+	LinkOption           *LinkOption                    `group:"Link Options"`
+	BulkOption           *BulkOption                    `group:"Bulk Options"`
+	SubscriptionOption   *internal.SubscriptionOption   `group:"Subscription Options"`
 	BrowseOption         *internal.BrowseOption         `group:"Browse Options"`
 }
 
 type CreateUpdateOption struct {
-	Edit        bool   `short:"e" long:"edit" description:"Edit the issue on editor. Start the editor with the contents in the given title and message options."`
-	Title       string `short:"i" long:"title" value-name:"<title>" description:"The title of an issue"`
-	Message     string `short:"m" long:"message" value-name:"<message>" description:"The message of an issue"`
-	Template    string `short:"p" long:"template" value-name:"<issue template>" description:"Start the editor with file using issue template"`
-	StateEvent  string `long:"state-event" value-name:"<state>" description:"Change the status. \"close\", \"reopen\""`
-	AssigneeID  int    `long:"cu-assignee-id" value-name:"<assignee id>" description:"The ID of the user to assign the issue to. If default_assignee_id is set in config, it is automatically entered"`
-	MilestoneID int    `long:"cu-milestone-id" value-name:"<milestone id>" description:"The global ID of a milestone to assign the issue to. "`
+	Edit       bool     `short:"e" long:"edit" description:"Edit the issue on editor. Start the editor with the contents in the given title and message options."`
+	Title      string   `short:"i" long:"title" value-name:"<title>" description:"The title of an issue"`
+	Message    string   `short:"m" long:"message" value-name:"<message>" description:"The message of an issue"`
+	Template   string   `short:"p" long:"template" value-name:"<issue template>" description:"Start the editor with file using issue template"`
+	StateEvent string   `long:"state-event" value-name:"<state>" description:"Change the status. \"close\", \"reopen\". Same as --close and --reopen"`
+	Assignee   string   `long:"cu-assignee-id" value-name:"<assignee>" description:"The ID or @username of the user to assign the issue to. If default_assignee_id is set in config, it is automatically entered"`
+	Milestone  string   `long:"cu-milestone-id" value-name:"<milestone>" description:"The title, %IID or global ID of a milestone to assign the issue to. "`
+	Assignees  []string `long:"assignee" value-name:"<username>" description:"The username to assign the issue to. Repeatable. \"name\" replaces the assignees, \"+name\" adds and \"-name\" (--assignee=-name) removes"`
+	Labels     []string `long:"label" value-name:"<label name>" description:"The label of the issue. Repeatable. Added to default_labels on creating. \"name\" replaces the labels on updating, \"+name\" adds and \"-name\" (--label=-name) removes"`
+	// Resolved from Assignee and Milestone by resolve
+	AssigneeID  int `no-flag:"true"`
+	MilestoneID int `no-flag:"true"`
 }
 
 func (o *CreateUpdateOption) hasEdit() bool {
@@ -33,7 +47,10 @@ func (o *CreateUpdateOption) hasEdit() bool {
 
 func (o *CreateUpdateOption) hasCreate() bool {
 	if o.Title != "" ||
+		o.Assignee != "" ||
+		o.Milestone != "" ||
 		o.AssigneeID != 0 ||
+		len(o.Assignees) > 0 ||
 		o.MilestoneID != 0 {
 		return true
 	}
@@ -44,13 +61,48 @@ func (o *CreateUpdateOption) hasUpdate() bool {
 	if o.Title != "" ||
 		o.Message != "" ||
 		o.StateEvent != "" ||
+		o.Assignee != "" ||
+		o.Milestone != "" ||
+		len(o.Labels) > 0 ||
 		o.AssigneeID != 0 ||
+		len(o.Assignees) > 0 ||
 		o.MilestoneID != 0 {
 		return true
 	}
 	return false
 }
 
+// resolve converts the assignee and milestone to the IDs, and the labels to the names in the project.
+func (o *CreateUpdateOption) resolve(resolver *internal.Resolver, project string) error {
+	if o.Assignee != "" {
+		id, err := resolver.UserID(o.Assignee)
+		if err != nil {
+			return err
+		}
+		o.AssigneeID = id
+	}
+	if o.Milestone != "" {
+		id, err := resolver.MilestoneID(project, o.Milestone)
+		if err != nil {
+			return err
+		}
+		o.MilestoneID = id
+	}
+	if len(o.Labels) > 0 {
+		labels, err := resolver.LabelChangeValues(project, o.Labels)
+		if err != nil {
+			return err
+		}
+		o.Labels = labels
+	}
+	return nil
+}
+
+// getCreateLabels returns the labels of a new issue, that are default_labels changed by --label.
+func (o *CreateUpdateOption) getCreateLabels(defaults []string) []string {
+	return internal.ParseLabelChange(o.Labels).AddTo(defaults)
+}
+
 func (o *CreateUpdateOption) getAssigneeID(profile *config.Profile) int {
 	if o.AssigneeID != 0 {
 		return o.AssigneeID
@@ -61,6 +113,26 @@ func (o *CreateUpdateOption) getAssigneeID(profile *config.Profile) int {
 	return 0
 }
 
+// getAssigneeIDs returns the IDs of the assignees changed from the current assignees by --assignee.
+// It returns nil when --assignee is not given.
+func (o *CreateUpdateOption) getAssigneeIDs(resolver *internal.Resolver, current []*gitlab.IssueAssignee) ([]int, error) {
+	if len(o.Assignees) == 0 {
+		return nil, nil
+	}
+	usernames := []string{}
+	for _, assignee := range current {
+		usernames = append(usernames, assignee.Username)
+	}
+	return resolver.ChangedUserIDs(usernames, o.Assignees)
+}
+
+func (o *CreateUpdateOption) getTemplate(profile *config.Profile) string {
+	if o.Template != "" {
+		return o.Template
+	}
+	return profile.IssueTemplate
+}
+
 type ListOption struct {
 	Num        int    `short:"n" long:"num" value-name:"<num>" default:"20" default-mask:"20" description:"Limit the number of issue to output."`
 	State      string `long:"state" value-name:"<state>" default:"all" default-mask:"all" description:"Print only issue of the state just those that are \"opened\", \"closed\" or \"all\""`
@@ -69,13 +141,51 @@ type ListOption struct {
 	Sort       string `long:"sort"  value-name:"<sort>" default:"desc" default-mask:"desc" description:"Print issue ordered in \"asc\" or \"desc\" order."`
 	Search     string `short:"s" long:"search"  value-name:"<search word>" description:"Search issues against their title and description."`
 	Milestone  string `long:"milestone"  value-name:"<milestone>" description:"Print issues for a specific milestone. "`
-	AuthorID   int    `long:"author-id"  value-name:"<auther id>" description:"Print issues created by the given user id"`
-	AssigneeID int    `long:"assignee-id"  value-name:"<assignee id>" description:"Print issues assigned to the given user id."`
+	Author     string `long:"author-id"  value-name:"<author>" description:"Print issues created by the given user id or @username"`
+	Assignee   string `long:"assignee-id"  value-name:"<assignee>" description:"Print issues assigned to the given user id or @username."`
 	Opened     bool   `short:"O" long:"opened" description:"Shorthand of the state option for \"--state=opened\"."`
 	Closed     bool   `short:"C" long:"closed" description:"Shorthand of the state option for \"--state=closed\"."`
 	CreatedMe  bool   `short:"r" long:"created-me" description:"Shorthand of the scope option for \"--scope=created-by-me\"."`
 	AssignedMe bool   `short:"a" long:"assigned-me" description:"Shorthand of the scope option for \"--scope=assigned-by-me\"."`
 	AllProject bool   `short:"A" long:"all-project" description:"Print the issue of all projects"`
+	Group      string `long:"group" value-name:"<group>" description:"Print the issue of all projects in the given group"`
+	// Resolved from Author and Assignee by resolve
+	AuthorID   int `no-flag:"true"`
+	AssigneeID int `no-flag:"true"`
+}
+
+func (l *ListOption) isValid() error {
+	if l.AllProject && l.Group != "" {
+		return fmt.Errorf("Cannot specify both --all-project and --group")
+	}
+	return nil
+}
+
+// resolve converts the author and assignee to the IDs, and checks the milestone of the project.
+// The milestone of the group or all projects is passed through, because it may not be in the project.
+func (l *ListOption) resolve(resolver *internal.Resolver, project string) error {
+	if l.Author != "" {
+		id, err := resolver.UserID(l.Author)
+		if err != nil {
+			return err
+		}
+		l.AuthorID = id
+	}
+	if l.Assignee != "" {
+		id, err := resolver.UserID(l.Assignee)
+		if err != nil {
+			return err
+		}
+		l.AssigneeID = id
+	}
+	if l.Milestone != "" && !l.AllProject && l.Group == "" && !internal.IsMilestoneWildcard(l.Milestone) {
+		title, err := resolver.MilestoneTitle(project, l.Milestone)
+		if err != nil {
+			return err
+		}
+		l.Milestone = title
+	}
+	return nil
 }
 
 func (l *ListOption) getState() string {
@@ -102,11 +212,62 @@ type ShowOption struct {
 	NoComment bool `long:"no-comment" description:"Not print a list of comments for a spcific issue."`
 }
 
+// linkTypes maps the accepted link types to the link types of the API
+var linkTypes = map[string]string{
+	"relates":       api.LinkTypeRelatesTo,
+	"relates_to":    api.LinkTypeRelatesTo,
+	"blocks":        api.LinkTypeBlocks,
+	"is_blocked_by": api.LinkTypeIsBlockedBy,
+}
+
+type LinkOption struct {
+	Link   int    `long:"link" value-name:"<issue id>" description:"Link the issue to the given issue"`
+	Unlink int    `long:"unlink" value-name:"<issue id>" description:"Remove the link to the given issue"`
+	Type   string `long:"type" value-name:"<link type>" description:"The type of the link. \"relates\", \"blocks\" or \"is_blocked_by\". The default is \"relates\""`
+}
+
+func (o *LinkOption) hasLink() bool {
+	return o.Link != 0 || o.Unlink != 0
+}
+
+func (o *LinkOption) isValid() error {
+	if o.Link != 0 && o.Unlink != 0 {
+		return fmt.Errorf("Cannot specify both --link and --unlink")
+	}
+	if o.Type != "" && o.Link == 0 {
+		return fmt.Errorf("--type requires --link")
+	}
+	if o.Type != "" {
+		if _, ok := linkTypes[o.Type]; !ok {
+			return fmt.Errorf("Invalid link type, %s. Choose from relates, blocks, is_blocked_by", o.Type)
+		}
+	}
+	return nil
+}
+
+func (o *LinkOption) getLinkType() string {
+	if o.Type == "" {
+		return api.LinkTypeRelatesTo
+	}
+	return linkTypes[o.Type]
+}
+
+type BulkOption struct {
+	Bulk    bool `long:"bulk" description:"Change every issue matching the list options, only the opened ones unless --state is given. Print the issues to change unless --apply is given"`
+	Apply   bool `long:"apply" description:"Change the issues by --bulk, instead of printing them"`
+	Workers int  `long:"workers" value-name:"<num>" default:"4" default-mask:"4" description:"The number of the concurrent API calls by --bulk"`
+}
+
 func newOptionParser(opt *Option) *flags.Parser {
 	opt.ProjectProfileOption = &internal.ProjectProfileOption{}
 	opt.CreateUpdateOption = &CreateUpdateOption{}
 	opt.ListOption = &ListOption{}
 	opt.ShowOption = &ShowOption{}
+	opt.StateOption = &internal.StateOption{}
+	opt.TimeTrackingOption = &internal.TimeTrackingOption{}
+	opt.LinkOption = &LinkOption{}
+	opt.BulkOption = &BulkOption{}
+	opt.SubscriptionOption = &internal.SubscriptionOption{}
 	opt.BrowseOption = &internal.BrowseOption{}
 	parser := flags.NewParser(opt, flags.HelpFlag|flags.PassDoubleDash)
 	parser.Usage = `issue - Create and Edit, List, Browse a issue
@@ -114,17 +275,39 @@ func newOptionParser(opt *Option) *flags.Parser {
 Synopsis:
   # List issue
   lab issue [-n <num>] [--state=<state> | -o | -c] [--scope=<scope> | -r | -a] [-s <search word>]
-            [--milestone=<milestone>] [--author-id=<author id>] [--assignee-id=<assignee id>]
-            [--orderby=<orderby>] [--sort=<sort>] [-A]
+            [--milestone=<milestone>] [--author-id=<author>] [--assignee-id=<assignee>]
+            [--orderby=<orderby>] [--sort=<sort>] [-A | --group=<group>]
 
   # Create issue
   lab issue -e | -i <title> [-m <message>]
-            [--cu-assignee-id=<assignee id>] [--cu-milestone-id=<milestone id>]
+            [--cu-assignee-id=<assignee>] [--cu-milestone-id=<milestone>]
+            [--assignee=<username>...] [--label=<label name>...]
 
   # Update issue
   lab issue <issue id> [-e] [-i <title>] [-m <message>]
                        [--state-event=<state>]
-                       [--cu-assignee-id=<assignee id>] [--cu-milestone-id=<milestone id>]
+                       [--cu-assignee-id=<assignee>] [--cu-milestone-id=<milestone>]
+                       [--assignee=[+|-]<username>...] [--label=<label name>...]
+
+  # Update issues matching the list options, printing them unless --apply is given
+  lab issue --bulk [--apply] [--workers=<num>]
+            [-s <search word>] [--milestone=<milestone>] [--state=<state>] ...
+            [--label=<label name>...] [--cu-milestone-id=<milestone>] [--assignee=[+|-]<username>...]
+            [--close | --reopen] [--lock | --unlock]
+
+  # Close, reopen, lock or delete issue
+  lab issue <issue id> [--close | --reopen] [--lock | --unlock]
+  lab issue <issue id> --delete [--yes]
+
+  # Track time of issue
+  lab issue <issue id> [--estimate=<duration>] [--spend=<duration> [--summary=<summary>]] [--reset-spent]
+
+  # Link issue
+  lab issue <issue id> --link=<issue id> [--type=<link type>]
+  lab issue <issue id> --unlink=<issue id>
+
+  # Subscribe issue
+  lab issue <issue id> --subscribe | --unsubscribe
 
   # Show issue
   lab issue <issue id> [--no-comment]
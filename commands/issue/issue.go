@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"strconv"
 
+	flags "github.com/jessevdk/go-flags"
+	"github.com/lighttiger2505/lab/commands/internal"
 	"github.com/lighttiger2505/lab/internal/api"
 	"github.com/lighttiger2505/lab/internal/gitutil"
 	"github.com/lighttiger2505/lab/internal/ui"
@@ -20,6 +22,7 @@ type IssueCommand struct {
 	UI              ui.UI
 	RemoteCollecter gitutil.Collecter
 	MethodFactory   MethodFactory
+	TokenRefresher  api.TokenRefresher
 }
 
 func (c *IssueCommand) Synopsis() string {
@@ -49,6 +52,44 @@ func (c *IssueCommand) Run(args []string) int {
 		return ExitCodeError
 	}
 
+	if err := opt.ListOption.isValid(); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+	if err := validBulkOption(opt, iid); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+	setBulkState(opt, parser)
+	if err := validStateOption(opt, iid); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+	if err := opt.TimeTrackingOption.IsValid(); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+	if opt.TimeTrackingOption.HasTimeTracking() && iid == 0 {
+		c.UI.Error("Invalid args, please input issue id to track the time")
+		return ExitCodeError
+	}
+	if err := opt.LinkOption.isValid(); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+	if opt.LinkOption.hasLink() && iid == 0 {
+		c.UI.Error("Invalid args, please input issue id to link")
+		return ExitCodeError
+	}
+	if err := opt.SubscriptionOption.IsValid(); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+	if opt.SubscriptionOption.HasSubscription() && iid == 0 {
+		c.UI.Error("Invalid args, please input issue id to subscribe")
+		return ExitCodeError
+	}
+
 	pInfo, err := c.RemoteCollecter.CollectTarget(
 		opt.ProjectProfileOption.Project,
 		opt.ProjectProfileOption.Profile,
@@ -58,12 +99,30 @@ func (c *IssueCommand) Run(args []string) int {
 		return ExitCodeError
 	}
 
-	clientFacotry, err := api.NewGitlabClientFactory(pInfo.ApiUrl(), pInfo.Token)
+	clientFacotry, err := api.NewGitlabClientFactory(pInfo.ApiUrl(), pInfo.Token, pInfo.TokenType, c.TokenRefresher)
 	if err != nil {
 		c.UI.Error(err.Error())
 		return ExitCodeError
 	}
 
+	resolver := internal.NewResolver(clientFacotry)
+	if err := opt.CreateUpdateOption.resolve(resolver, pInfo.Project); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+	if err := opt.ListOption.resolve(resolver, pInfo.Project); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+
+	if opt.StateOption.Delete {
+		target := fmt.Sprintf("issue #%d of %s", iid, pInfo.Project)
+		if err := internal.ConfirmDelete(c.UI, target, opt.StateOption.Yes); err != nil {
+			c.UI.Error(err.Error())
+			return ExitCodeError
+		}
+	}
+
 	method := c.MethodFactory.CreateMethod(opt, pInfo, iid, clientFacotry)
 	res, err := method.Process()
 	if err != nil {
@@ -89,3 +148,69 @@ func validIssueIID(args []string) (int, error) {
 	}
 	return iid, nil
 }
+
+// validStateOption checks the state actions, and --delete that cannot be combined with the other changes.
+func validStateOption(opt Option, iid int) error {
+	if err := opt.StateOption.IsValid(); err != nil {
+		return err
+	}
+	if !opt.StateOption.HasState() {
+		return nil
+	}
+	if iid == 0 && !opt.BulkOption.Bulk {
+		return fmt.Errorf("Invalid args, please input issue id to change the state")
+	}
+	if opt.CreateUpdateOption.StateEvent != "" {
+		return fmt.Errorf("Cannot specify both --state-event and the state options")
+	}
+	if opt.StateOption.Delete && (opt.CreateUpdateOption.hasUpdate() ||
+		opt.CreateUpdateOption.hasEdit() ||
+		opt.TimeTrackingOption.HasTimeTracking() ||
+		opt.LinkOption.hasLink() ||
+		opt.SubscriptionOption.HasSubscription()) {
+		return fmt.Errorf("Cannot specify --delete with the other changes of the issue")
+	}
+	return nil
+}
+
+// validBulkOption checks that --bulk changes the issues of the project, by the options applicable to every issue.
+func validBulkOption(opt Option, iid int) error {
+	bulkOpt := opt.BulkOption
+	if !bulkOpt.Bulk {
+		if bulkOpt.Apply {
+			return fmt.Errorf("--apply requires --bulk")
+		}
+		return nil
+	}
+	if iid != 0 {
+		return fmt.Errorf("Cannot specify both issue id and --bulk")
+	}
+	if opt.ListOption.AllProject || opt.ListOption.Group != "" {
+		return fmt.Errorf("Cannot specify --bulk with --all-project or --group")
+	}
+	if bulkOpt.Workers < 1 {
+		return fmt.Errorf("Invalid workers %d, please input a positive number", bulkOpt.Workers)
+	}
+	createUpdateOpt := opt.CreateUpdateOption
+	if createUpdateOpt.Title != "" || createUpdateOpt.Message != "" || createUpdateOpt.hasEdit() || createUpdateOpt.Template != "" {
+		return fmt.Errorf("Cannot specify --title, --message, --edit or --template with --bulk")
+	}
+	if opt.StateOption.Delete {
+		return fmt.Errorf("Cannot specify both --delete and --bulk")
+	}
+	if !createUpdateOpt.hasUpdate() && !opt.StateOption.HasState() {
+		return fmt.Errorf("Please specify the changes of the issues by --bulk")
+	}
+	return nil
+}
+
+// setBulkState leaves the closed issues alone by --bulk, unless --state is given.
+func setBulkState(opt Option, parser *flags.Parser) {
+	if !opt.BulkOption.Bulk {
+		return
+	}
+	if state := parser.FindOptionByLongName("state"); state != nil && state.IsSet() && !state.IsSetDefault() {
+		return
+	}
+	opt.ListOption.State = "opened"
+}
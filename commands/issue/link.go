@@ -0,0 +1,56 @@
+package issue
+
+import (
+	"fmt"
+
+	"github.com/lighttiger2505/lab/commands/internal"
+	"github.com/lighttiger2505/lab/internal/api"
+)
+
+type linkMethod struct {
+	// method runs before linking, that is nil when the issue is not updated.
+	method      internal.Method
+	client      api.IssueLink
+	issueClient api.Issue
+	opt         *LinkOption
+	project     string
+	id          int
+}
+
+func (m *linkMethod) Process() (string, error) {
+	res := ""
+	if m.method != nil {
+		out, err := m.method.Process()
+		if err != nil {
+			return "", err
+		}
+		res = out
+	}
+
+	if m.opt.Link != 0 {
+		if err := m.client.CreateIssueLink(m.project, m.id, m.opt.Link, m.opt.getLinkType()); err != nil {
+			return "", err
+		}
+		return res, nil
+	}
+
+	// The link is removed by its ID, so it is found from the links of the issue.
+	// The linked issues can be in the other projects with the same IID, so the project is compared too.
+	source, err := m.issueClient.GetIssue(m.id, m.project)
+	if err != nil {
+		return "", err
+	}
+	issues, err := m.client.ListIssueLinks(m.project, m.id)
+	if err != nil {
+		return "", err
+	}
+	for _, issue := range issues {
+		if issue.IID == m.opt.Unlink && issue.ProjectID == source.ProjectID {
+			if err := m.client.DeleteIssueLink(m.project, m.id, issue.IssueLinkID); err != nil {
+				return "", err
+			}
+			return res, nil
+		}
+	}
+	return "", fmt.Errorf("Not found link to the issue, #%d", m.opt.Unlink)
+}
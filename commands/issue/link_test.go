@@ -0,0 +1,108 @@
+package issue
+
+import (
+	"testing"
+
+	"github.com/lighttiger2505/lab/internal/api"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+func Test_linkMethod_Process(t *testing.T) {
+	links := []*api.LinkedIssue{
+		&api.LinkedIssue{IssueLinkID: 6, LinkType: api.LinkTypeRelatesTo},
+		&api.LinkedIssue{IssueLinkID: 7, LinkType: api.LinkTypeBlocks},
+	}
+	// The issue of the other project with the same IID
+	links[0].IID, links[0].ProjectID = 13, 2
+	links[1].IID, links[1].ProjectID = 13, 1
+
+	tests := []struct {
+		name       string
+		opt        *LinkOption
+		wantCreate string
+		wantDelete int
+		wantErr    bool
+	}{
+		{
+			name:       "link",
+			opt:        &LinkOption{Link: 13},
+			wantCreate: api.LinkTypeRelatesTo,
+		},
+		{
+			name:       "link with type",
+			opt:        &LinkOption{Link: 13, Type: "blocks"},
+			wantCreate: api.LinkTypeBlocks,
+		},
+		{
+			name:       "unlink",
+			opt:        &LinkOption{Unlink: 13},
+			wantDelete: 7,
+		},
+		{
+			name:    "unlink not linked issue",
+			opt:     &LinkOption{Unlink: 14},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created, deleted := "", 0
+			m := &linkMethod{
+				client: &api.MockIssueLinkClient{
+					MockListIssueLinks: func(project string, iid int) ([]*api.LinkedIssue, error) {
+						return links, nil
+					},
+					MockCreateIssueLink: func(project string, iid, targetIID int, linkType string) error {
+						if iid != 12 || targetIID != 13 {
+							t.Errorf("invalid link, #%d to #%d", iid, targetIID)
+						}
+						created = linkType
+						return nil
+					},
+					MockDeleteIssueLink: func(project string, iid, linkID int) error {
+						deleted = linkID
+						return nil
+					},
+				},
+				issueClient: &api.MockLabIssueClient{
+					MockGetIssue: func(pid int, repositoryName string) (*gitlab.Issue, error) {
+						return &gitlab.Issue{IID: pid, ProjectID: 1}, nil
+					},
+				},
+				opt:     tt.opt,
+				project: "group/project",
+				id:      12,
+			}
+			_, err := m.Process()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Process() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if created != tt.wantCreate {
+				t.Errorf("invalid link type, got %q, want %q", created, tt.wantCreate)
+			}
+			if deleted != tt.wantDelete {
+				t.Errorf("invalid deleted link, got %d, want %d", deleted, tt.wantDelete)
+			}
+		})
+	}
+}
+
+func TestLinkOption_isValid(t *testing.T) {
+	tests := []struct {
+		name    string
+		opt     *LinkOption
+		wantErr bool
+	}{
+		{name: "link", opt: &LinkOption{Link: 1, Type: "is_blocked_by"}},
+		{name: "both link and unlink", opt: &LinkOption{Link: 1, Unlink: 2}, wantErr: true},
+		{name: "type without link", opt: &LinkOption{Unlink: 2, Type: "blocks"}, wantErr: true},
+		{name: "unknown type", opt: &LinkOption{Link: 1, Type: "duplicates"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.opt.isValid(); (err != nil) != tt.wantErr {
+				t.Errorf("isValid() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -46,6 +46,22 @@ func (m *listAllMethod) Process() (string, error) {
 	return result, nil
 }
 
+type listGroupMethod struct {
+	client api.Issue
+	opt    *ListOption
+}
+
+func (m *listGroupMethod) Process() (string, error) {
+	issues, err := m.client.GetGroupIssues(makeGroupIssueOption(m.opt), m.opt.Group)
+	if err != nil {
+		return "", err
+	}
+
+	output := listAllOutput(issues)
+	result := columnize.SimpleFormat(output)
+	return result, nil
+}
+
 func makeProjectIssueOption(issueListOption *ListOption) *gitlab.ListProjectIssuesOptions {
 	listOption := &gitlab.ListOptions{
 		Page:    1,
@@ -98,6 +114,32 @@ func makeAllProjectIssueOption(issueListOption *ListOption) *gitlab.ListIssuesOp
 	return listIssuesOptions
 }
 
+func makeGroupIssueOption(issueListOption *ListOption) *gitlab.ListGroupIssuesOptions {
+	listOption := &gitlab.ListOptions{
+		Page:    1,
+		PerPage: issueListOption.Num,
+	}
+	listGroupIssuesOptions := &gitlab.ListGroupIssuesOptions{
+		State:       gitlab.String(issueListOption.getState()),
+		Scope:       gitlab.String(issueListOption.getScope()),
+		OrderBy:     gitlab.String(issueListOption.OrderBy),
+		Sort:        gitlab.String(issueListOption.Sort),
+		Search:      gitlab.String(issueListOption.Search),
+		ListOptions: *listOption,
+	}
+
+	if issueListOption.Milestone != "" {
+		listGroupIssuesOptions.Milestone = gitlab.String(issueListOption.Milestone)
+	}
+	if issueListOption.AuthorID != 0 {
+		listGroupIssuesOptions.AuthorID = gitlab.Int(issueListOption.AuthorID)
+	}
+	if issueListOption.AssigneeID != 0 {
+		listGroupIssuesOptions.AssigneeID = gitlab.Int(issueListOption.AssigneeID)
+	}
+	return listGroupIssuesOptions
+}
+
 func listOutput(issues []*gitlab.Issue) []string {
 	yellow := color.New(color.FgYellow).SprintFunc()
 	var datas []string
@@ -0,0 +1,52 @@
+package issue
+
+import (
+	"github.com/lighttiger2505/lab/commands/internal"
+	"github.com/lighttiger2505/lab/internal/api"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+// stateMethod closes, reopens, locks or unlocks the issue.
+type stateMethod struct {
+	// method runs before changing the state, that is nil when the issue is not updated.
+	method  internal.Method
+	client  api.Issue
+	opt     *internal.StateOption
+	project string
+	id      int
+}
+
+func (m *stateMethod) Process() (string, error) {
+	res := ""
+	if m.method != nil {
+		out, err := m.method.Process()
+		if err != nil {
+			return "", err
+		}
+		res = out
+	}
+
+	updateOpt := &gitlab.UpdateIssueOptions{
+		DiscussionLocked: m.opt.GetDiscussionLocked(),
+	}
+	if stateEvent := m.opt.GetStateEvent(); stateEvent != "" {
+		updateOpt.StateEvent = gitlab.String(stateEvent)
+	}
+	if _, err := m.client.UpdateIssue(updateOpt, m.id, m.project); err != nil {
+		return "", err
+	}
+	return res, nil
+}
+
+type deleteMethod struct {
+	client  api.Issue
+	project string
+	id      int
+}
+
+func (m *deleteMethod) Process() (string, error) {
+	if err := m.client.DeleteIssue(m.id, m.project); err != nil {
+		return "", err
+	}
+	return "", nil
+}
@@ -0,0 +1,87 @@
+package issue
+
+import (
+	"testing"
+
+	"github.com/lighttiger2505/lab/commands/internal"
+	"github.com/lighttiger2505/lab/internal/api"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+func Test_stateMethod_Process(t *testing.T) {
+	tests := []struct {
+		name           string
+		opt            *internal.StateOption
+		wantStateEvent string
+		wantLocked     string
+	}{
+		{name: "close", opt: &internal.StateOption{Close: true}, wantStateEvent: "close", wantLocked: "-"},
+		{name: "reopen and lock", opt: &internal.StateOption{Reopen: true, Lock: true}, wantStateEvent: "reopen", wantLocked: "true"},
+		{name: "unlock", opt: &internal.StateOption{Unlock: true}, wantLocked: "false"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &stateMethod{
+				client: &api.MockLabIssueClient{
+					MockUpdateIssue: func(opt *gitlab.UpdateIssueOptions, pid int, repositoryName string) (*gitlab.Issue, error) {
+						stateEvent := ""
+						if opt.StateEvent != nil {
+							stateEvent = *opt.StateEvent
+						}
+						if stateEvent != tt.wantStateEvent {
+							t.Errorf("invalid state event, got %q, want %q", stateEvent, tt.wantStateEvent)
+						}
+						locked := "-"
+						if opt.DiscussionLocked != nil {
+							locked = "false"
+							if *opt.DiscussionLocked {
+								locked = "true"
+							}
+						}
+						if locked != tt.wantLocked {
+							t.Errorf("invalid discussion locked, got %s, want %s", locked, tt.wantLocked)
+						}
+						return &gitlab.Issue{}, nil
+					},
+				},
+				opt:     tt.opt,
+				project: "group/project",
+				id:      12,
+			}
+			if _, err := m.Process(); err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+		})
+	}
+}
+
+func Test_validStateOption(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		iid     int
+		wantErr bool
+	}{
+		{name: "close", args: []string{"--close", "--lock"}, iid: 12},
+		{name: "close and update", args: []string{"--close", "-i", "title"}, iid: 12},
+		{name: "without issue id", args: []string{"--close"}, wantErr: true},
+		{name: "both close and reopen", args: []string{"--close", "--reopen"}, iid: 12, wantErr: true},
+		{name: "with state event", args: []string{"--close", "--state-event", "close"}, iid: 12, wantErr: true},
+		{name: "delete", args: []string{"--delete", "--yes"}, iid: 12},
+		{name: "delete and update", args: []string{"--delete", "-i", "title"}, iid: 12, wantErr: true},
+		{name: "delete and close", args: []string{"--delete", "--close"}, iid: 12, wantErr: true},
+		{name: "yes without delete", args: []string{"--close", "--yes"}, iid: 12, wantErr: true},
+		{name: "only yes", args: []string{"--yes"}, iid: 12, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opt Option
+			if _, err := newOptionParser(&opt).ParseArgs(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			if err := validStateOption(opt, tt.iid); (err != nil) != tt.wantErr {
+				t.Errorf("validStateOption() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
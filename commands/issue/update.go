@@ -23,11 +23,33 @@ func makeUpdateIssueOption(opt *CreateUpdateOption, title, description string) *
 	return updateIssueOption
 }
 
+// setUpdateLabels changes the labels of the issue by --label.
+func setUpdateLabels(updateOpt *gitlab.UpdateIssueOptions, opt *CreateUpdateOption, issue *gitlab.Issue) {
+	if len(opt.Labels) == 0 {
+		return
+	}
+	labels := gitlab.Labels(internal.ParseLabelChange(opt.Labels).Apply(issue.Labels))
+	updateOpt.Labels = &labels
+}
+
+// setUpdateAssignees changes the assignees of the issue by --assignee.
+func setUpdateAssignees(updateOpt *gitlab.UpdateIssueOptions, opt *CreateUpdateOption, resolver *internal.Resolver, issue *gitlab.Issue) error {
+	ids, err := opt.getAssigneeIDs(resolver, issue.Assignees)
+	if err != nil {
+		return err
+	}
+	if ids != nil {
+		updateOpt.AssigneeIDs = internal.UpdateAssigneeIDs(ids)
+	}
+	return nil
+}
+
 type updateMethod struct {
-	client  api.Issue
-	opt     *CreateUpdateOption
-	project string
-	id      int
+	client   api.Issue
+	resolver *internal.Resolver
+	opt      *CreateUpdateOption
+	project  string
+	id       int
 }
 
 func (m *updateMethod) Process() (string, error) {
@@ -41,11 +63,12 @@ func (m *updateMethod) Process() (string, error) {
 	updatedTitle, updatedMessage := getUpdatedTitleAndMessage(issue, m.opt.Title, m.opt.Message)
 
 	// Do update issue
-	_, err = m.client.UpdateIssue(
-		makeUpdateIssueOption(m.opt, updatedTitle, updatedMessage),
-		m.id,
-		m.project,
-	)
+	updateOpt := makeUpdateIssueOption(m.opt, updatedTitle, updatedMessage)
+	if err := setUpdateAssignees(updateOpt, m.opt, m.resolver, issue); err != nil {
+		return "", err
+	}
+	setUpdateLabels(updateOpt, m.opt, issue)
+	_, err = m.client.UpdateIssue(updateOpt, m.id, m.project)
 	if err != nil {
 		return "", err
 	}
@@ -57,6 +80,7 @@ func (m *updateMethod) Process() (string, error) {
 type updateOnEditorMethod struct {
 	internal.Method
 	client   api.Issue
+	resolver *internal.Resolver
 	opt      *CreateUpdateOption
 	project  string
 	id       int
@@ -84,11 +108,12 @@ func (m *updateOnEditorMethod) Process() (string, error) {
 	}
 
 	// Do update issue
-	_, err = m.client.UpdateIssue(
-		makeUpdateIssueOption(m.opt, title, message),
-		m.id,
-		m.project,
-	)
+	updateOpt := makeUpdateIssueOption(m.opt, title, message)
+	if err := setUpdateAssignees(updateOpt, m.opt, m.resolver, issue); err != nil {
+		return "", err
+	}
+	setUpdateLabels(updateOpt, m.opt, issue)
+	_, err = m.client.UpdateIssue(updateOpt, m.id, m.project)
 	if err != nil {
 		return "", err
 	}
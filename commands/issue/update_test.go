@@ -185,6 +185,52 @@ func Test_updateMethod_Process(t *testing.T) {
 			want:    "",
 			wantErr: false,
 		},
+		{
+			name: "add and remove assignees",
+			method: &updateMethod{
+				client: &api.MockLabIssueClient{
+					MockGetIssue: func(pid int, repositoryName string) (*gitlab.Issue, error) {
+						return &gitlab.Issue{
+							Title:       "title",
+							Description: "desc",
+							Assignees: []*gitlab.IssueAssignee{
+								&gitlab.IssueAssignee{ID: 24, Username: "carol"},
+								&gitlab.IssueAssignee{ID: 25, Username: "dave"},
+							},
+						}, nil
+					},
+					MockUpdateIssue: func(opt *gitlab.UpdateIssueOptions, pid int, repositoryName string) (*gitlab.Issue, error) {
+						got := opt
+						want := &gitlab.UpdateIssueOptions{
+							Title:       gitlab.String("title"),
+							Description: gitlab.String("desc"),
+							AssigneeIDs: []int{25, 13},
+						}
+						if diff := cmp.Diff(got, want); diff != "" {
+							t.Errorf("invalide arg (-got +want)\n%s", diff)
+						}
+						return issue, nil
+					},
+				},
+				resolver: internal.NewResolver(&api.MockAPIClientFactory{
+					MockGetUserClient: func() api.User {
+						return &api.MockUserClient{
+							MockUsers: func(opt *gitlab.ListUsersOptions) ([]*gitlab.User, error) {
+								ids := map[string]int{"alice": 13, "dave": 25}
+								return []*gitlab.User{&gitlab.User{ID: ids[*opt.Username]}}, nil
+							},
+						}
+					},
+				}),
+				opt: &CreateUpdateOption{
+					Assignees: []string{"+alice", "-carol"},
+				},
+				project: "group/project",
+				id:      12,
+			},
+			want:    "",
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -397,3 +443,34 @@ func Test_updateOnEditorMethod_Process(t *testing.T) {
 		})
 	}
 }
+
+func Test_setUpdateLabels(t *testing.T) {
+	issue := &gitlab.Issue{Labels: gitlab.Labels{"bug", "doing"}}
+	tests := []struct {
+		name   string
+		labels []string
+		want   *gitlab.Labels
+	}{
+		{name: "no change", labels: nil, want: nil},
+		{name: "replace", labels: []string{"feature"}, want: &gitlab.Labels{"feature"}},
+		{name: "add and remove", labels: []string{"+feature", "-doing"}, want: &gitlab.Labels{"bug", "feature"}},
+		{name: "remove all", labels: []string{"-bug", "-doing"}, want: &gitlab.Labels{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updateOpt := &gitlab.UpdateIssueOptions{}
+			setUpdateLabels(updateOpt, &CreateUpdateOption{Labels: tt.labels}, issue)
+			if diff := cmp.Diff(updateOpt.Labels, tt.want); diff != "" {
+				t.Errorf("setUpdateLabels() differs: (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreateUpdateOption_getCreateLabels(t *testing.T) {
+	opt := &CreateUpdateOption{Labels: []string{"bug", "+doing", "-triage"}}
+	want := []string{"team", "bug", "doing"}
+	if diff := cmp.Diff(opt.getCreateLabels([]string{"team", "triage"}), want); diff != "" {
+		t.Errorf("getCreateLabels() differs: (-got +want)\n%s", diff)
+	}
+}
@@ -60,18 +60,23 @@ func (c *IssueTemplateCommand) Run(args []string) int {
 		return ExitCodeError
 	}
 
-	if err := c.ClientFactory.Init(pInfo.ApiUrl(), pInfo.Token); err != nil {
+	if err := c.ClientFactory.Init(pInfo.ApiUrl(), pInfo.Token, pInfo.TokenType); err != nil {
 		c.UI.Error(err.Error())
 		return ExitCodeError
 	}
 	client := c.ClientFactory.GetRepositoryClient()
+	ref, err := c.ClientFactory.GetProjectClient().DefaultBranch(pInfo.Project)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
 
 	if len(parceArgs) > 0 {
 		filename := IssueTemplateDir + "/" + parceArgs[0]
 		res, err := client.GetFile(
 			pInfo.Project,
 			filename,
-			makeShowIssueTemplateOption(),
+			makeShowIssueTemplateOption(ref),
 		)
 		if err != nil {
 			c.UI.Error(err.Error())
@@ -81,7 +86,7 @@ func (c *IssueTemplateCommand) Run(args []string) int {
 	} else {
 		treeNode, err := client.GetTree(
 			pInfo.Project,
-			makeIssueTemplateOption(),
+			makeIssueTemplateOption(ref),
 		)
 		if err != nil {
 			c.UI.Error(err.Error())
@@ -94,17 +99,17 @@ func (c *IssueTemplateCommand) Run(args []string) int {
 	return ExitCodeOK
 }
 
-func makeIssueTemplateOption() *gitlab.ListTreeOptions {
+func makeIssueTemplateOption(ref string) *gitlab.ListTreeOptions {
 	opt := &gitlab.ListTreeOptions{
 		Path: gitlab.String(IssueTemplateDir),
-		Ref:  gitlab.String("master"),
+		Ref:  gitlab.String(ref),
 	}
 	return opt
 }
 
-func makeShowIssueTemplateOption() *gitlab.GetRawFileOptions {
+func makeShowIssueTemplateOption(ref string) *gitlab.GetRawFileOptions {
 	opt := &gitlab.GetRawFileOptions{
-		Ref: gitlab.String("master"),
+		Ref: gitlab.String(ref),
 	}
 	return opt
 }
@@ -34,7 +34,7 @@ func (c *LintCommand) Run(args []string) int {
 		return ExitCodeError
 	}
 
-	if err := c.ClientFactory.Init(pInfo.ApiUrl(), pInfo.Token); err != nil {
+	if err := c.ClientFactory.Init(pInfo.ApiUrl(), pInfo.Token, pInfo.TokenType); err != nil {
 		c.UI.Error(err.Error())
 		return ExitCodeError
 	}
@@ -60,18 +60,23 @@ func (c *MergeRequestTemplateCommand) Run(args []string) int {
 		return ExitCodeError
 	}
 
-	if err := c.ClientFactory.Init(pInfo.ApiUrl(), pInfo.Token); err != nil {
+	if err := c.ClientFactory.Init(pInfo.ApiUrl(), pInfo.Token, pInfo.TokenType); err != nil {
 		c.UI.Error(err.Error())
 		return ExitCodeError
 	}
 	client := c.ClientFactory.GetRepositoryClient()
+	ref, err := c.ClientFactory.GetProjectClient().DefaultBranch(pInfo.Project)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
 
 	if len(parceArgs) > 0 {
 		filename := MergeRequestTemplateDir + "/" + parceArgs[0]
 		res, err := client.GetFile(
 			pInfo.Project,
 			filename,
-			makeShowMergeRequestTemplateOption(),
+			makeShowMergeRequestTemplateOption(ref),
 		)
 		if err != nil {
 			c.UI.Error(err.Error())
@@ -81,7 +86,7 @@ func (c *MergeRequestTemplateCommand) Run(args []string) int {
 	} else {
 		treeNode, err := client.GetTree(
 			pInfo.Project,
-			makeMergeRequestTemplateOption(),
+			makeMergeRequestTemplateOption(ref),
 		)
 		if err != nil {
 			c.UI.Error(err.Error())
@@ -94,17 +99,17 @@ func (c *MergeRequestTemplateCommand) Run(args []string) int {
 	return ExitCodeOK
 }
 
-func makeMergeRequestTemplateOption() *gitlab.ListTreeOptions {
+func makeMergeRequestTemplateOption(ref string) *gitlab.ListTreeOptions {
 	opt := &gitlab.ListTreeOptions{
 		Path: gitlab.String(MergeRequestTemplateDir),
-		Ref:  gitlab.String("master"),
+		Ref:  gitlab.String(ref),
 	}
 	return opt
 }
 
-func makeShowMergeRequestTemplateOption() *gitlab.GetRawFileOptions {
+func makeShowMergeRequestTemplateOption(ref string) *gitlab.GetRawFileOptions {
 	opt := &gitlab.GetRawFileOptions{
-		Ref: gitlab.String("master"),
+		Ref: gitlab.String(ref),
 	}
 	return opt
 }
@@ -0,0 +1,48 @@
+package milestone
+
+import (
+	"fmt"
+
+	"github.com/lighttiger2505/lab/internal/api"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+type createMethod struct {
+	client  api.Milestone
+	opt     *CreateUpdateOption
+	project string
+}
+
+func (m *createMethod) Process() (string, error) {
+	createOpt, err := makeCreateMilestoneOptions(m.opt)
+	if err != nil {
+		return "", err
+	}
+	milestone, err := m.client.CreateMilestone(m.project, createOpt)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d", milestone.ID), nil
+}
+
+func makeCreateMilestoneOptions(opt *CreateUpdateOption) (*gitlab.CreateMilestoneOptions, error) {
+	createOpt := &gitlab.CreateMilestoneOptions{
+		Title: gitlab.String(opt.Title),
+	}
+	if opt.Message != "" {
+		createOpt.Description = gitlab.String(opt.Message)
+	}
+
+	startDate, err := parseDate(opt.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	createOpt.StartDate = startDate
+
+	dueDate, err := parseDate(opt.DueDate)
+	if err != nil {
+		return nil, err
+	}
+	createOpt.DueDate = dueDate
+	return createOpt, nil
+}
@@ -0,0 +1,159 @@
+package milestone
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/lighttiger2505/lab/internal/api"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+var now = time.Now
+
+type detailMethod struct {
+	client  api.Milestone
+	opt     *ShowOption
+	project string
+	id      int
+}
+
+func (m *detailMethod) Process() (string, error) {
+	milestone, err := m.client.GetMilestone(m.project, m.id)
+	if err != nil {
+		return "", err
+	}
+	res := milestoneDetailOutput(milestone)
+
+	if !m.opt.Report {
+		return res, nil
+	}
+
+	issues, err := m.client.GetMilestoneIssues(m.project, m.id)
+	if err != nil {
+		return "", err
+	}
+	mergeRequests, err := m.client.GetMilestoneMergeRequests(m.project, m.id)
+	if err != nil {
+		return "", err
+	}
+	report := newReport(milestone, issues, mergeRequests, now())
+	return strings.Join([]string{res, reportOutput(report)}, "\n"), nil
+}
+
+func milestoneDetailOutput(milestone *gitlab.Milestone) string {
+	base := `%s %s [%s]
+Start date: %s
+Due date: %s
+
+%s
+`
+	yellow := color.New(color.FgYellow).SprintFunc()
+	var stateColor func(a ...interface{}) string
+	if milestone.State == "active" {
+		stateColor = color.New(color.FgGreen).SprintFunc()
+	} else {
+		stateColor = color.New(color.FgRed).SprintFunc()
+	}
+
+	return fmt.Sprintf(
+		base,
+		yellow(milestone.ID),
+		milestone.Title,
+		stateColor(milestone.State),
+		dateOutput(milestone.StartDate),
+		dateOutput(milestone.DueDate),
+		milestone.Description,
+	)
+}
+
+// report is the progress of a milestone
+type report struct {
+	OpenedIssues       int
+	ClosedIssues       int
+	OpenedMergeRequest int
+	MergedMergeRequest int
+	ClosedMergeRequest int
+	// Overdue is the open items whose due date has passed
+	Overdue []string
+}
+
+// Percent is the ratio of the closed issues, the same as the progress of GitLab.
+func (r *report) Percent() int {
+	total := r.OpenedIssues + r.ClosedIssues
+	if total == 0 {
+		return 0
+	}
+	return r.ClosedIssues * 100 / total
+}
+
+// newReport counts the issues and merge requests. An open issue is overdue when its due date,
+// or the due date of the milestone for the issue without it, is before today.
+// An open merge request is overdue when the due date of the milestone is before today.
+func newReport(milestone *gitlab.Milestone, issues []*gitlab.Issue, mergeRequests []*gitlab.MergeRequest, today time.Time) *report {
+	r := &report{Overdue: []string{}}
+	for _, issue := range issues {
+		if issue.State == "closed" {
+			r.ClosedIssues++
+			continue
+		}
+		r.OpenedIssues++
+
+		dueDate := milestone.DueDate
+		if issue.DueDate != nil {
+			dueDate = issue.DueDate
+		}
+		if isPast(dueDate, today) {
+			r.Overdue = append(r.Overdue, fmt.Sprintf("#%d %s (due %s)", issue.IID, issue.Title, dueDate.String()))
+		}
+	}
+
+	for _, mergeRequest := range mergeRequests {
+		switch mergeRequest.State {
+		case "merged":
+			r.MergedMergeRequest++
+		case "closed":
+			r.ClosedMergeRequest++
+		default:
+			r.OpenedMergeRequest++
+			if isPast(milestone.DueDate, today) {
+				r.Overdue = append(r.Overdue, fmt.Sprintf("!%d %s (due %s)", mergeRequest.IID, mergeRequest.Title, milestone.DueDate.String()))
+			}
+		}
+	}
+	return r
+}
+
+func isPast(date *gitlab.ISOTime, today time.Time) bool {
+	if date == nil {
+		return false
+	}
+	return date.String() < today.Format(dateLayout)
+}
+
+func reportOutput(r *report) string {
+	base := `Progress: %d%% complete
+Issues: %d open, %d closed
+Merge requests: %d open, %d merged, %d closed
+`
+	res := fmt.Sprintf(
+		base,
+		r.Percent(),
+		r.OpenedIssues,
+		r.ClosedIssues,
+		r.OpenedMergeRequest,
+		r.MergedMergeRequest,
+		r.ClosedMergeRequest,
+	)
+	if len(r.Overdue) == 0 {
+		return res
+	}
+
+	red := color.New(color.FgRed).SprintFunc()
+	overdue := []string{red(fmt.Sprintf("Overdue: %d", len(r.Overdue)))}
+	for _, item := range r.Overdue {
+		overdue = append(overdue, "  "+item)
+	}
+	return res + strings.Join(overdue, "\n")
+}
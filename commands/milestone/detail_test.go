@@ -0,0 +1,172 @@
+package milestone
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lighttiger2505/lab/internal/api"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+func isoDate(value string) *gitlab.ISOTime {
+	date, _ := parseDate(value)
+	return date
+}
+
+func Test_newReport(t *testing.T) {
+	today, _ := time.Parse(dateLayout, "2019-03-10")
+	tests := []struct {
+		name      string
+		milestone *gitlab.Milestone
+		issues    []*gitlab.Issue
+		mrs       []*gitlab.MergeRequest
+		want      *report
+		percent   int
+	}{
+		{
+			name:      "empty",
+			milestone: &gitlab.Milestone{},
+			want:      &report{Overdue: []string{}},
+			percent:   0,
+		},
+		{
+			name:      "in time",
+			milestone: &gitlab.Milestone{DueDate: isoDate("2019-03-31")},
+			issues: []*gitlab.Issue{
+				&gitlab.Issue{IID: 1, State: "closed"},
+				&gitlab.Issue{IID: 2, State: "opened", Title: "late", DueDate: isoDate("2019-03-09")},
+				&gitlab.Issue{IID: 3, State: "opened", Title: "today", DueDate: isoDate("2019-03-10")},
+			},
+			mrs: []*gitlab.MergeRequest{
+				&gitlab.MergeRequest{IID: 4, State: "opened"},
+				&gitlab.MergeRequest{IID: 5, State: "merged"},
+				&gitlab.MergeRequest{IID: 6, State: "closed"},
+			},
+			want: &report{
+				OpenedIssues:       2,
+				ClosedIssues:       1,
+				OpenedMergeRequest: 1,
+				MergedMergeRequest: 1,
+				ClosedMergeRequest: 1,
+				Overdue:            []string{"#2 late (due 2019-03-09)"},
+			},
+			percent: 33,
+		},
+		{
+			name:      "past due milestone",
+			milestone: &gitlab.Milestone{DueDate: isoDate("2019-03-01")},
+			issues: []*gitlab.Issue{
+				&gitlab.Issue{IID: 1, State: "closed"},
+				&gitlab.Issue{IID: 2, State: "opened", Title: "no due date"},
+				&gitlab.Issue{IID: 3, State: "opened", Title: "extended", DueDate: isoDate("2019-03-20")},
+				&gitlab.Issue{IID: 7, State: "closed"},
+			},
+			mrs: []*gitlab.MergeRequest{
+				&gitlab.MergeRequest{IID: 4, State: "opened", Title: "wip"},
+			},
+			want: &report{
+				OpenedIssues:       2,
+				ClosedIssues:       2,
+				OpenedMergeRequest: 1,
+				Overdue: []string{
+					"#2 no due date (due 2019-03-01)",
+					"!4 wip (due 2019-03-01)",
+				},
+			},
+			percent: 50,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newReport(tt.milestone, tt.issues, tt.mrs, today)
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("invalid report (-got +want)\n%s", diff)
+			}
+			if got.Percent() != tt.percent {
+				t.Errorf("Percent() = %d, want %d", got.Percent(), tt.percent)
+			}
+		})
+	}
+}
+
+func Test_detailMethod_Process(t *testing.T) {
+	now = func() time.Time {
+		today, _ := time.Parse(dateLayout, "2019-03-10")
+		return today
+	}
+	defer func() { now = time.Now }()
+
+	client := &api.MockMilestoneClient{
+		MockGetMilestone: func(project string, id int) (*gitlab.Milestone, error) {
+			return &gitlab.Milestone{
+				ID:          12,
+				Title:       "v1.0",
+				State:       "active",
+				Description: "first release",
+				StartDate:   isoDate("2019-03-01"),
+				DueDate:     isoDate("2019-03-05"),
+			}, nil
+		},
+		MockGetMilestoneIssues: func(project string, id int) ([]*gitlab.Issue, error) {
+			return []*gitlab.Issue{
+				&gitlab.Issue{IID: 1, State: "closed"},
+				&gitlab.Issue{IID: 2, State: "opened", Title: "login"},
+			}, nil
+		},
+		MockGetMilestoneMergeRequests: func(project string, id int) ([]*gitlab.MergeRequest, error) {
+			return []*gitlab.MergeRequest{
+				&gitlab.MergeRequest{IID: 3, State: "merged"},
+			}, nil
+		},
+	}
+
+	want := `12 v1.0 [active]
+Start date: 2019-03-01
+Due date: 2019-03-05
+
+first release
+
+Progress: 50% complete
+Issues: 1 open, 1 closed
+Merge requests: 0 open, 1 merged, 0 closed
+Overdue: 1
+  #2 login (due 2019-03-05)`
+
+	m := &detailMethod{
+		client:  client,
+		opt:     &ShowOption{Report: true},
+		project: "group/project",
+		id:      12,
+	}
+	got, err := m.Process()
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("Process() = %q, want %q", got, want)
+	}
+}
+
+func Test_makeUpdateMilestoneOptions(t *testing.T) {
+	got, err := makeUpdateMilestoneOptions(&CreateUpdateOption{
+		DueDate: "2019-04-01",
+		Reopen:  true,
+	})
+	if err != nil {
+		t.Fatalf("makeUpdateMilestoneOptions() error = %v", err)
+	}
+	if got.DueDate == nil || got.DueDate.String() != "2019-04-01" {
+		t.Errorf("invalid due date, got %v", got.DueDate)
+	}
+	if got.StartDate != nil || got.Title != nil {
+		t.Errorf("not given options must be nil, got %v, %v", got.StartDate, got.Title)
+	}
+	if got.StateEvent == nil || *got.StateEvent != "activate" {
+		t.Errorf("invalid state event, got %v", got.StateEvent)
+	}
+
+	if _, err := makeUpdateMilestoneOptions(&CreateUpdateOption{StartDate: "2019/04/01"}); err == nil {
+		t.Errorf("makeUpdateMilestoneOptions() want error for invalid date")
+	}
+}
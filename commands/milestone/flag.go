@@ -0,0 +1,117 @@
+package milestone
+
+import (
+	"fmt"
+	"time"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/lighttiger2505/lab/commands/internal"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+// dateLayout is the format of the start and due dates
+const dateLayout = "2006-01-02"
+
+type Option struct {
+	ProjectProfileOption *internal.ProjectProfileOption `group:"Project, Profile Options"`
+	CreateUpdateOption   *CreateUpdateOption            `group:"Create, Update Options"`
+	ListOption           *ListOption                    `group:"List Options"`
+	ShowOption           *ShowOption                    `group:"Show Options"`
+}
+
+type CreateUpdateOption struct {
+	Title     string `short:"i" long:"title" value-name:"<title>" description:"The title of a milestone"`
+	Message   string `short:"m" long:"message" value-name:"<message>" description:"The description of a milestone"`
+	StartDate string `long:"start-date" value-name:"<YYYY-MM-DD>" description:"The start date of a milestone"`
+	DueDate   string `long:"due-date" value-name:"<YYYY-MM-DD>" description:"The due date of a milestone"`
+	Close     bool   `long:"close" description:"Close the milestone"`
+	Reopen    bool   `long:"reopen" description:"Reopen the closed milestone"`
+}
+
+func (o *CreateUpdateOption) isValid() error {
+	if o.Close && o.Reopen {
+		return fmt.Errorf("Cannot specify both --close and --reopen")
+	}
+	if _, err := parseDate(o.StartDate); err != nil {
+		return err
+	}
+	if _, err := parseDate(o.DueDate); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (o *CreateUpdateOption) hasCreate() bool {
+	return o.Title != ""
+}
+
+func (o *CreateUpdateOption) hasUpdate() bool {
+	if o.Title != "" ||
+		o.Message != "" ||
+		o.StartDate != "" ||
+		o.DueDate != "" ||
+		o.Close ||
+		o.Reopen {
+		return true
+	}
+	return false
+}
+
+// getStateEvent returns the state event of the milestone API, that calls reopening "activate".
+func (o *CreateUpdateOption) getStateEvent() string {
+	if o.Close {
+		return "close"
+	}
+	if o.Reopen {
+		return "activate"
+	}
+	return ""
+}
+
+type ListOption struct {
+	Num   int    `short:"n" long:"num" value-name:"<num>" default:"20" default-mask:"20" description:"Limit the number of milestone to output."`
+	State string `long:"state" value-name:"<state>" description:"Print only given state. \"active\", \"closed\""`
+	Group string `long:"group" value-name:"<group>" description:"Print the milestones of the given group instead of the project. Only for listing"`
+}
+
+type ShowOption struct {
+	Report bool `short:"r" long:"report" description:"Show the progress of the issues and merge requests in the milestone"`
+}
+
+func newOptionParser(opt *Option) *flags.Parser {
+	opt.ProjectProfileOption = &internal.ProjectProfileOption{}
+	opt.CreateUpdateOption = &CreateUpdateOption{}
+	opt.ListOption = &ListOption{}
+	opt.ShowOption = &ShowOption{}
+	parser := flags.NewParser(opt, flags.HelpFlag|flags.PassDoubleDash)
+	parser.Usage = `milestone - Create and Edit, List, Report a milestone
+
+Synopsis:
+  # List milestone
+  lab milestone [-n <num>] [--state=<state>] [--group=<group>]
+
+  # Create milestone
+  lab milestone -i <title> [-m <message>] [--start-date=<YYYY-MM-DD>] [--due-date=<YYYY-MM-DD>]
+
+  # Update milestone
+  lab milestone <milestone id> [-i <title>] [-m <message>]
+                               [--start-date=<YYYY-MM-DD>] [--due-date=<YYYY-MM-DD>]
+                               [--close | --reopen]
+
+  # Show milestone
+  lab milestone <milestone id> [-r]`
+	return parser
+}
+
+// parseDate parses the date option. The empty value is nil.
+func parseDate(value string) (*gitlab.ISOTime, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid date, %s. Please input YYYY-MM-DD", value)
+	}
+	date := gitlab.ISOTime(t)
+	return &date, nil
+}
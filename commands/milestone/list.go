@@ -0,0 +1,105 @@
+package milestone
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/lighttiger2505/lab/internal/api"
+	"github.com/ryanuber/columnize"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+type listMethod struct {
+	client  api.Milestone
+	opt     *ListOption
+	project string
+}
+
+func (m *listMethod) Process() (string, error) {
+	milestones, err := m.client.ListMilestones(
+		m.project,
+		makeListMilestoneOptions(m.opt),
+	)
+	if err != nil {
+		return "", err
+	}
+	return columnize.SimpleFormat(milestoneOutput(milestones)), nil
+}
+
+type listGroupMethod struct {
+	client api.Milestone
+	opt    *ListOption
+}
+
+func (m *listGroupMethod) Process() (string, error) {
+	milestones, err := m.client.ListGroupMilestones(
+		m.opt.Group,
+		makeListGroupMilestoneOptions(m.opt),
+	)
+	if err != nil {
+		return "", err
+	}
+	return columnize.SimpleFormat(groupMilestoneOutput(milestones)), nil
+}
+
+func makeListMilestoneOptions(listOption *ListOption) *gitlab.ListMilestonesOptions {
+	lopt := &gitlab.ListOptions{
+		Page:    1,
+		PerPage: listOption.Num,
+	}
+	opt := &gitlab.ListMilestonesOptions{
+		ListOptions: *lopt,
+	}
+	if listOption.State != "" {
+		opt.State = gitlab.String(listOption.State)
+	}
+	return opt
+}
+
+func makeListGroupMilestoneOptions(listOption *ListOption) *gitlab.ListGroupMilestonesOptions {
+	lopt := &gitlab.ListOptions{
+		Page:    1,
+		PerPage: listOption.Num,
+	}
+	return &gitlab.ListGroupMilestonesOptions{
+		ListOptions: *lopt,
+		State:       listOption.State,
+	}
+}
+
+func milestoneOutput(milestones []*gitlab.Milestone) []string {
+	yellow := color.New(color.FgYellow).SprintFunc()
+	var outputs []string
+	for _, milestone := range milestones {
+		output := strings.Join([]string{
+			yellow(milestone.ID),
+			milestone.Title,
+			dateOutput(milestone.DueDate),
+			milestone.Description,
+		}, "|")
+		outputs = append(outputs, output)
+	}
+	return outputs
+}
+
+func groupMilestoneOutput(milestones []*gitlab.GroupMilestone) []string {
+	yellow := color.New(color.FgYellow).SprintFunc()
+	var outputs []string
+	for _, milestone := range milestones {
+		output := strings.Join([]string{
+			yellow(milestone.ID),
+			milestone.Title,
+			dateOutput(milestone.DueDate),
+			milestone.Description,
+		}, "|")
+		outputs = append(outputs, output)
+	}
+	return outputs
+}
+
+func dateOutput(date *gitlab.ISOTime) string {
+	if date == nil {
+		return ""
+	}
+	return date.String()
+}
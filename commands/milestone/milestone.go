@@ -2,16 +2,13 @@ package milestone
 
 import (
 	"bytes"
-	"strings"
+	"fmt"
+	"strconv"
 
-	"github.com/fatih/color"
-	flags "github.com/jessevdk/go-flags"
 	"github.com/lighttiger2505/lab/commands/internal"
 	"github.com/lighttiger2505/lab/internal/api"
 	"github.com/lighttiger2505/lab/internal/gitutil"
 	"github.com/lighttiger2505/lab/internal/ui"
-	"github.com/ryanuber/columnize"
-	gitlab "github.com/xanzy/go-gitlab"
 )
 
 const (
@@ -19,30 +16,6 @@ const (
 	ExitCodeError int = iota //1
 )
 
-type Option struct {
-	ProjectProfileOption *internal.ProjectProfileOption `group:"Project, Profile Options"`
-	ListOption           *ListOption                    `group:"List Options"`
-}
-
-type ListOption struct {
-	Num int `short:"n" long:"num" value-name:"<num>" default:"20" default-mask:"20" description:"Limit the number of milestone to output."`
-}
-
-func newOptionParser(opt *Option) *flags.Parser {
-	opt.ProjectProfileOption = &internal.ProjectProfileOption{}
-	opt.ListOption = &ListOption{}
-	parser := flags.NewParser(opt, flags.HelpFlag|flags.PassDoubleDash)
-	parser.Usage = `config - Edit and Show config
-
-Synopsis:
-  # Edit config
-  lab config
-
-  # Show config
-  lab config -l`
-	return parser
-}
-
 type MilestoneCommand struct {
 	UI              ui.UI
 	RemoteCollecter gitutil.Collecter
@@ -50,7 +23,7 @@ type MilestoneCommand struct {
 }
 
 func (c *MilestoneCommand) Synopsis() string {
-	return "List milestone"
+	return "Create and Edit, List, Report a milestone"
 }
 
 func (c *MilestoneCommand) Help() string {
@@ -64,12 +37,28 @@ func (c *MilestoneCommand) Help() string {
 func (c *MilestoneCommand) Run(args []string) int {
 	var opt Option
 	parser := newOptionParser(&opt)
-	_, err := parser.ParseArgs(args)
+	parseArgs, err := parser.ParseArgs(args)
 	if err != nil {
 		c.UI.Error(err.Error())
 		return ExitCodeError
 	}
 
+	id, err := validID(parseArgs)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+
+	if err := opt.CreateUpdateOption.isValid(); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+
+	if err := validMilestoneOption(opt, id); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+
 	pInfo, err := c.RemoteCollecter.CollectTarget(
 		opt.ProjectProfileOption.Project,
 		opt.ProjectProfileOption.Profile,
@@ -79,49 +68,96 @@ func (c *MilestoneCommand) Run(args []string) int {
 		return ExitCodeError
 	}
 
-	if err := c.ClientFactory.Init(pInfo.ApiUrl(), pInfo.Token); err != nil {
+	if err := c.ClientFactory.Init(pInfo.ApiUrl(), pInfo.Token, pInfo.TokenType); err != nil {
 		c.UI.Error(err.Error())
 		return ExitCodeError
 	}
-	client := c.ClientFactory.GetMilestoneClient()
 
-	milestones, err := client.ListMilestones(
-		pInfo.Project,
-		makeListMilestoneOptions(opt.ListOption),
-	)
+	method := c.createMethod(id, opt, pInfo)
+	res, err := method.Process()
 	if err != nil {
 		c.UI.Error(err.Error())
 		return ExitCodeError
 	}
 
-	output := milestoneOutput(milestones)
-	result := columnize.SimpleFormat(output)
-	c.UI.Message(result)
+	if res != "" {
+		c.UI.Message(res)
+	}
 
 	return ExitCodeOK
 }
 
-func makeListMilestoneOptions(listOption *ListOption) *gitlab.ListMilestonesOptions {
-	lopt := &gitlab.ListOptions{
-		Page:    1,
-		PerPage: listOption.Num,
+func (c *MilestoneCommand) createMethod(id int, opt Option, pInfo *gitutil.GitLabProjectInfo) internal.Method {
+	client := c.ClientFactory.GetMilestoneClient()
+
+	if id > 0 {
+		if opt.CreateUpdateOption.hasUpdate() {
+			return &updateMethod{
+				client:  client,
+				opt:     opt.CreateUpdateOption,
+				project: pInfo.Project,
+				id:      id,
+			}
+		}
+		return &detailMethod{
+			client:  client,
+			opt:     opt.ShowOption,
+			project: pInfo.Project,
+			id:      id,
+		}
+	}
+
+	if opt.CreateUpdateOption.hasCreate() {
+		return &createMethod{
+			client:  client,
+			opt:     opt.CreateUpdateOption,
+			project: pInfo.Project,
+		}
+	}
+
+	if opt.ListOption.Group != "" {
+		return &listGroupMethod{
+			client: client,
+			opt:    opt.ListOption,
+		}
+	}
+
+	return &listMethod{
+		client:  client,
+		opt:     opt.ListOption,
+		project: pInfo.Project,
+	}
+}
+
+func validID(args []string) (int, error) {
+	if len(args) < 1 {
+		return 0, nil
 	}
-	opt := &gitlab.ListMilestonesOptions{
-		ListOptions: *lopt,
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return 0, fmt.Errorf("Invalid args, please input milestone id.")
 	}
-	return opt
+	return id, nil
 }
 
-func milestoneOutput(milestones []*gitlab.Milestone) []string {
-	yellow := color.New(color.FgYellow).SprintFunc()
-	var outputs []string
-	for _, milestone := range milestones {
-		output := strings.Join([]string{
-			yellow(milestone.ID),
-			milestone.Title,
-			milestone.Description,
-		}, "|")
-		outputs = append(outputs, output)
-	}
-	return outputs
+// validMilestoneOption checks that the options changing or showing a milestone are given with the milestone id,
+// and that --group only lists the milestones.
+// Without the id, only the title, the description and the dates are used to create a milestone.
+func validMilestoneOption(opt Option, id int) error {
+	createUpdateOpt := opt.CreateUpdateOption
+	// The group milestones are only listed, the others are the project milestones
+	if opt.ListOption.Group != "" && (id > 0 || createUpdateOpt.hasCreate()) {
+		return fmt.Errorf("Cannot specify --group with the milestone id or --title. --group only lists the milestones")
+	}
+	if id > 0 {
+		return nil
+	}
+	if createUpdateOpt.Close || createUpdateOpt.Reopen || opt.ShowOption.Report {
+		return fmt.Errorf("Invalid args, please input milestone id")
+	}
+	if createUpdateOpt.hasUpdate() && !createUpdateOpt.hasCreate() {
+		return fmt.Errorf("Invalid args, please input milestone id")
+	}
+	return nil
 }
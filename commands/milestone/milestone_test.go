@@ -0,0 +1,36 @@
+package milestone
+
+import "testing"
+
+func Test_validMilestoneOption(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		id      int
+		wantErr bool
+	}{
+		{name: "list", args: []string{"--state", "active"}},
+		{name: "create", args: []string{"-i", "v1.0", "-m", "message", "--due-date", "2019-01-02"}},
+		{name: "update", args: []string{"--close"}, id: 3},
+		{name: "report", args: []string{"-r"}, id: 3},
+		{name: "close without id", args: []string{"--close"}, wantErr: true},
+		{name: "reopen with title", args: []string{"-i", "v1.0", "--reopen"}, wantErr: true},
+		{name: "message without id", args: []string{"-m", "message"}, wantErr: true},
+		{name: "date without id", args: []string{"--start-date", "2019-01-02"}, wantErr: true},
+		{name: "report without id", args: []string{"-r"}, wantErr: true},
+		{name: "list group", args: []string{"--group", "group"}},
+		{name: "group with id", args: []string{"--group", "group"}, id: 3, wantErr: true},
+		{name: "create in group", args: []string{"--group", "group", "-i", "v1.0"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opt Option
+			if _, err := newOptionParser(&opt).ParseArgs(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			if err := validMilestoneOption(opt, tt.id); (err != nil) != tt.wantErr {
+				t.Errorf("validMilestoneOption() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package milestone
+
+import (
+	"github.com/lighttiger2505/lab/internal/api"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+type updateMethod struct {
+	client  api.Milestone
+	opt     *CreateUpdateOption
+	project string
+	id      int
+}
+
+func (m *updateMethod) Process() (string, error) {
+	updateOpt, err := makeUpdateMilestoneOptions(m.opt)
+	if err != nil {
+		return "", err
+	}
+	if _, err := m.client.UpdateMilestone(m.project, m.id, updateOpt); err != nil {
+		return "", err
+	}
+	return "", nil
+}
+
+// makeUpdateMilestoneOptions sets only the given options, to keep the others.
+func makeUpdateMilestoneOptions(opt *CreateUpdateOption) (*gitlab.UpdateMilestoneOptions, error) {
+	updateOpt := &gitlab.UpdateMilestoneOptions{}
+	if opt.Title != "" {
+		updateOpt.Title = gitlab.String(opt.Title)
+	}
+	if opt.Message != "" {
+		updateOpt.Description = gitlab.String(opt.Message)
+	}
+	if stateEvent := opt.getStateEvent(); stateEvent != "" {
+		updateOpt.StateEvent = gitlab.String(stateEvent)
+	}
+
+	startDate, err := parseDate(opt.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	updateOpt.StartDate = startDate
+
+	dueDate, err := parseDate(opt.DueDate)
+	if err != nil {
+		return nil, err
+	}
+	updateOpt.DueDate = dueDate
+	return updateOpt, nil
+}
@@ -2,6 +2,8 @@ package mr
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/lighttiger2505/lab/commands/internal"
 	"github.com/lighttiger2505/lab/git"
@@ -12,25 +14,34 @@ import (
 
 type createMethod struct {
 	internal.Method
-	client api.MergeRequest
-	opt    *CreateUpdateOption
-	pInfo  *gitutil.GitLabProjectInfo
+	client        api.MergeRequest
+	resolver      *internal.Resolver
+	projectClient api.Project
+	pusher        *sourceBranchPusher
+	opt           *CreateUpdateOption
+	pInfo         *gitutil.GitLabProjectInfo
 }
 
 func (m *createMethod) Process() (string, error) {
-	// Get source branch. current branch from local repository when non specific flags
-	currentBranch, err := git.CurrentBranch()
+	currentBranch, err := getSourceBranch(m.opt)
 	if err != nil {
 		return "", err
 	}
-	if m.opt.SourceBranch != "" {
-		// TODO Checking source branch exitst
-		currentBranch = m.opt.SourceBranch
+	if err := m.pusher.ensure(currentBranch); err != nil {
+		return "", err
+	}
+
+	target, err := findTarget(m.projectClient, m.opt, m.pInfo.Project)
+	if err != nil {
+		return "", err
 	}
 
 	// Do create merge request
-	mergeRequest, err := m.client.CreateMergeRequest(
-		makeCreateMergeRequestOption(m.opt, m.opt.Title, m.opt.Message, currentBranch, m.pInfo),
+	mergeRequest, err := createMergeRequest(
+		m.client,
+		m.resolver,
+		m.opt,
+		makeCreateMergeRequestOption(m.opt, m.opt.Title, m.opt.Message, currentBranch, m.pInfo, target),
 		m.pInfo.Project,
 	)
 	if err != nil {
@@ -44,7 +55,12 @@ func (m *createMethod) Process() (string, error) {
 type createOnEditorMethod struct {
 	internal.Method
 	client           api.MergeRequest
+	resolver         *internal.Resolver
 	repositoryClient api.Repository
+	projectClient    api.Project
+	branchClient     api.Branch
+	gitClient        git.Client
+	pusher           *sourceBranchPusher
 	opt              *CreateUpdateOption
 	pInfo            *gitutil.GitLabProjectInfo
 	editFunc         func(program, file string) error
@@ -53,14 +69,27 @@ type createOnEditorMethod struct {
 const templateDir = ".gitlab/merge_request_templates"
 
 func (m *createOnEditorMethod) Process() (string, error) {
-	templateFilename := m.opt.Template
+	// Push the branch before editing, so that the edit is not lost by the failure
+	currentBranch, err := getSourceBranch(m.opt)
+	if err != nil {
+		return "", err
+	}
+	if err := m.pusher.ensure(currentBranch); err != nil {
+		return "", err
+	}
+
+	templateFilename := m.opt.getTemplate(m.pInfo.Profile)
 	var template string
 	if templateFilename != "" {
+		ref, err := m.projectClient.DefaultBranch(m.pInfo.Project)
+		if err != nil {
+			return "", err
+		}
 		filename := templateDir + "/" + templateFilename
 		res, err := m.repositoryClient.GetFile(
 			m.pInfo.Project,
 			filename,
-			makeMergeRequestTemplateOption(),
+			makeMergeRequestTemplateOption(ref),
 		)
 		if err != nil {
 			return "", err
@@ -68,14 +97,18 @@ func (m *createOnEditorMethod) Process() (string, error) {
 		template = res
 	}
 
-	var title, message string
-	title = m.opt.Title
-	message = template
-	if m.opt.Message != "" {
-		message = m.opt.Message
+	target, err := findTarget(m.projectClient, m.opt, m.pInfo.Project)
+	if err != nil {
+		return "", err
 	}
 
-	title, message, err := internal.EditTitleAndDesc(
+	title, message := prefillTitleAndDesc(
+		m.opt,
+		template,
+		m.commitLog(target, currentBranch),
+		git.CommentChar(),
+	)
+	title, message, err = internal.EditTitleAndDesc(
 		"MERGE_REQUEST",
 		internal.EditContents(title, message),
 		m.editFunc,
@@ -84,19 +117,12 @@ func (m *createOnEditorMethod) Process() (string, error) {
 		return "", err
 	}
 
-	// Get source branch. current branch from local repository when non specific flags
-	currentBranch, err := git.CurrentBranch()
-	if err != nil {
-		return "", err
-	}
-	if m.opt.SourceBranch != "" {
-		// TODO Checking source branch exitst
-		currentBranch = m.opt.SourceBranch
-	}
-
 	// Do create merge request
-	mergeRequest, err := m.client.CreateMergeRequest(
-		makeCreateMergeRequestOption(m.opt, title, message, currentBranch, m.pInfo),
+	mergeRequest, err := createMergeRequest(
+		m.client,
+		m.resolver,
+		m.opt,
+		makeCreateMergeRequestOption(m.opt, title, message, currentBranch, m.pInfo, target),
 		m.pInfo.Project,
 	)
 	if err != nil {
@@ -107,13 +133,140 @@ func (m *createOnEditorMethod) Process() (string, error) {
 	return fmt.Sprintf("%d", mergeRequest.IID), nil
 }
 
-func makeCreateMergeRequestOption(opt *CreateUpdateOption, title, description, branch string, pInfo *gitutil.GitLabProjectInfo) *gitlab.CreateMergeRequestOptions {
+// createMergeRequest creates the merge request with the assignees and reviewers given by the usernames.
+func createMergeRequest(client api.MergeRequest, resolver *internal.Resolver, opt *CreateUpdateOption, createOpt *gitlab.CreateMergeRequestOptions, project string) (*gitlab.MergeRequest, error) {
+	assigneeIDs, err := opt.getAssigneeIDs(resolver, nil)
+	if err != nil {
+		return nil, err
+	}
+	if assigneeIDs != nil {
+		createOpt.AssigneeID = nil
+		createOpt.AssigneeIDs = assigneeIDs
+	}
+	reviewerIDs, err := opt.getReviewerIDs(resolver, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.CreateMergeRequest(&api.CreateMergeRequestOptions{
+		CreateMergeRequestOptions: *createOpt,
+		ReviewerIDs:               reviewerIDs,
+	}, project)
+}
+
+// commitLog returns the commits of the source branch that are not in the target branch.
+// It returns nil when the commits cannot be listed, e.g. the target branch is not fetched,
+// because the prefill is only a help for editing.
+func (m *createOnEditorMethod) commitLog(target *mergeTarget, source string) []*git.Commit {
+	project := m.pInfo.Project
+	if target.projectID != nil {
+		project = strconv.Itoa(*target.projectID)
+	}
+	targetBranch, err := m.branchClient.GetBranch(project, m.opt.getTargetBranch(m.pInfo.Profile, target.defaultBranch))
+	if err != nil || targetBranch == nil || targetBranch.Commit == nil {
+		return nil
+	}
+	commits, err := m.gitClient.CommitLog(targetBranch.Commit.ID, source)
+	if err != nil {
+		return nil
+	}
+	return commits
+}
+
+// prefillTitleAndDesc makes the title and description opened in the editor.
+// A single commit becomes the title and description,
+// and several commits are listed below the scissors line that is removed after editing.
+func prefillTitleAndDesc(opt *CreateUpdateOption, template string, commits []*git.Commit, cs string) (string, string) {
+	title := opt.Title
+	message := template
+	if opt.Message != "" {
+		message = opt.Message
+	}
+
+	switch {
+	case len(commits) == 1:
+		if title == "" {
+			title = commits[0].Subject
+		}
+		if opt.Message == "" && commits[0].Body != "" {
+			message = strings.TrimSpace(commits[0].Body + "\n\n" + template)
+		}
+	case len(commits) > 1:
+		lines := []string{"", fmt.Sprintf("%d commits:", len(commits))}
+		for _, c := range commits {
+			lines = append(lines, fmt.Sprintf("%s %s", c.Hash, c.Subject))
+		}
+		message = strings.TrimRight(message, "\n") + "\n\n" + git.Scissors(cs, lines)
+	}
+	return title, message
+}
+
+// getSourceBranch returns the source branch option, or the current branch of the local repository.
+func getSourceBranch(opt *CreateUpdateOption) (string, error) {
+	if opt.SourceBranch != "" {
+		return opt.SourceBranch, nil
+	}
+	return git.CurrentBranch()
+}
+
+// findUpstream returns the parent project when the merge request should target the upstream of a fork.
+// It returns nil when the merge request targets the project itself.
+func findUpstream(client api.Project, opt *CreateUpdateOption, project string) (*gitlab.Project, error) {
+	if opt.NoUpstream {
+		return nil, nil
+	}
+
+	p, err := client.GetProject(project)
+	if err != nil {
+		return nil, err
+	}
+	if p.ForkedFromProject == nil {
+		if opt.Upstream {
+			return nil, fmt.Errorf("%s is not a fork", project)
+		}
+		return nil, nil
+	}
+
+	// The fork parent in the project does not have the default branch
+	return client.GetProject(strconv.Itoa(p.ForkedFromProject.ID))
+}
+
+// mergeTarget is the project that the merge request targets.
+type mergeTarget struct {
+	// projectID is nil when the merge request targets the source project itself
+	projectID     *int
+	defaultBranch string
+}
+
+func findTarget(client api.Project, opt *CreateUpdateOption, project string) (*mergeTarget, error) {
+	upstream, err := findUpstream(client, opt, project)
+	if err != nil {
+		return nil, err
+	}
+
+	target := &mergeTarget{}
+	if upstream != nil {
+		target.projectID = gitlab.Int(upstream.ID)
+		project = strconv.Itoa(upstream.ID)
+	}
+	target.defaultBranch, err = client.DefaultBranch(project)
+	if err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+func makeCreateMergeRequestOption(opt *CreateUpdateOption, title, description, branch string, pInfo *gitutil.GitLabProjectInfo, target *mergeTarget) *gitlab.CreateMergeRequestOptions {
 	createMergeRequestOption := &gitlab.CreateMergeRequestOptions{
-		Title:           gitlab.String(title),
+		Title:           gitlab.String(opt.applyDraft(title)),
 		Description:     gitlab.String(description),
 		SourceBranch:    gitlab.String(branch),
-		TargetBranch:    gitlab.String(opt.TargetBranch),
-		TargetProjectID: nil,
+		TargetBranch:    gitlab.String(opt.getTargetBranch(pInfo.Profile, target.defaultBranch)),
+		TargetProjectID: target.projectID,
+	}
+	if labels := opt.getCreateLabels(pInfo.Profile.DefaultLabels); len(labels) > 0 {
+		gitlabLabels := gitlab.Labels(labels)
+		createMergeRequestOption.Labels = &gitlabLabels
 	}
 	assigneeID := opt.getAssigneeID(pInfo.Profile)
 	if assigneeID != 0 {
@@ -123,17 +276,15 @@ func makeCreateMergeRequestOption(opt *CreateUpdateOption, title, description, b
 		createMergeRequestOption.MilestoneID = gitlab.Int(opt.MilestoneID)
 	}
 
-	_, removeSourceBranchFlag := opt.RemoveSourceBranchFlag()
-	createMergeRequestOption.RemoveSourceBranch = gitlab.Bool(removeSourceBranchFlag)
-	_, squashFlag := opt.SquashFlag()
-	createMergeRequestOption.Squash = gitlab.Bool(squashFlag)
+	createMergeRequestOption.RemoveSourceBranch = gitlab.Bool(opt.getRemoveSourceBranch(pInfo.Profile))
+	createMergeRequestOption.Squash = gitlab.Bool(opt.getSquash(pInfo.Profile))
 
 	return createMergeRequestOption
 }
 
-func makeMergeRequestTemplateOption() *gitlab.GetRawFileOptions {
+func makeMergeRequestTemplateOption(ref string) *gitlab.GetRawFileOptions {
 	opt := &gitlab.GetRawFileOptions{
-		Ref: gitlab.String("master"),
+		Ref: gitlab.String(ref),
 	}
 	return opt
 }
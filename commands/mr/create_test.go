@@ -0,0 +1,279 @@
+package mr
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lighttiger2505/lab/commands/internal"
+	"github.com/lighttiger2505/lab/git"
+	"github.com/lighttiger2505/lab/internal/api"
+	"github.com/lighttiger2505/lab/internal/config"
+	"github.com/lighttiger2505/lab/internal/gitutil"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+var forkProjectClient = &api.MockProjectClient{
+	MockGetProject: func(project string) (*gitlab.Project, error) {
+		switch project {
+		case "fork/repo":
+			return &gitlab.Project{
+				ID:                2,
+				DefaultBranch:     "master",
+				ForkedFromProject: &gitlab.ForkParent{ID: 1, PathWithNamespace: "upstream/repo"},
+			}, nil
+		case "1":
+			return &gitlab.Project{ID: 1, DefaultBranch: "develop"}, nil
+		}
+		return &gitlab.Project{ID: 3, DefaultBranch: "main"}, nil
+	},
+	MockDefaultBranch: func(project string) (string, error) {
+		if project == "1" {
+			return "develop", nil
+		}
+		return "main", nil
+	},
+}
+
+func Test_findUpstream(t *testing.T) {
+	tests := []struct {
+		name    string
+		opt     *CreateUpdateOption
+		project string
+		wantID  int
+		wantErr bool
+	}{
+		{name: "fork", opt: &CreateUpdateOption{}, project: "fork/repo", wantID: 1},
+		{name: "fork with upstream", opt: &CreateUpdateOption{Upstream: true}, project: "fork/repo", wantID: 1},
+		{name: "fork with no upstream", opt: &CreateUpdateOption{NoUpstream: true}, project: "fork/repo", wantID: 0},
+		{name: "not fork", opt: &CreateUpdateOption{}, project: "group/repo", wantID: 0},
+		{name: "not fork with upstream", opt: &CreateUpdateOption{Upstream: true}, project: "group/repo", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findUpstream(forkProjectClient, tt.opt, tt.project)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("findUpstream() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			gotID := 0
+			if got != nil {
+				gotID = got.ID
+			}
+			if gotID != tt.wantID {
+				t.Errorf("findUpstream() = %d, want %d", gotID, tt.wantID)
+			}
+		})
+	}
+}
+
+func Test_findTarget(t *testing.T) {
+	tests := []struct {
+		name    string
+		opt     *CreateUpdateOption
+		project string
+		want    *mergeTarget
+	}{
+		{
+			name:    "fork",
+			opt:     &CreateUpdateOption{},
+			project: "fork/repo",
+			want:    &mergeTarget{projectID: gitlab.Int(1), defaultBranch: "develop"},
+		},
+		{
+			name:    "not fork",
+			opt:     &CreateUpdateOption{},
+			project: "group/repo",
+			want:    &mergeTarget{defaultBranch: "main"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findTarget(forkProjectClient, tt.opt, tt.project)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(got, tt.want, cmp.AllowUnexported(mergeTarget{})); diff != "" {
+				t.Errorf("Invalid result: (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_makeCreateMergeRequestOption(t *testing.T) {
+	pInfo := &gitutil.GitLabProjectInfo{Project: "fork/repo", Profile: &config.Profile{}}
+	upstream := &mergeTarget{projectID: gitlab.Int(1), defaultBranch: "develop"}
+
+	tests := []struct {
+		name     string
+		opt      *CreateUpdateOption
+		upstream *mergeTarget
+		want     *gitlab.CreateMergeRequestOptions
+	}{
+		{
+			name:     "upstream",
+			opt:      &CreateUpdateOption{},
+			upstream: upstream,
+			want: &gitlab.CreateMergeRequestOptions{
+				Title:              gitlab.String("title"),
+				Description:        gitlab.String("message"),
+				SourceBranch:       gitlab.String("feature"),
+				TargetBranch:       gitlab.String("develop"),
+				TargetProjectID:    gitlab.Int(1),
+				RemoveSourceBranch: gitlab.Bool(false),
+				Squash:             gitlab.Bool(false),
+			},
+		},
+		{
+			name:     "upstream with target branch",
+			opt:      &CreateUpdateOption{TargetBranch: "release"},
+			upstream: upstream,
+			want: &gitlab.CreateMergeRequestOptions{
+				Title:              gitlab.String("title"),
+				Description:        gitlab.String("message"),
+				SourceBranch:       gitlab.String("feature"),
+				TargetBranch:       gitlab.String("release"),
+				TargetProjectID:    gitlab.Int(1),
+				RemoveSourceBranch: gitlab.Bool(false),
+				Squash:             gitlab.Bool(false),
+			},
+		},
+		{
+			name:     "same project",
+			opt:      &CreateUpdateOption{},
+			upstream: &mergeTarget{defaultBranch: "main"},
+			want: &gitlab.CreateMergeRequestOptions{
+				Title:              gitlab.String("title"),
+				Description:        gitlab.String("message"),
+				SourceBranch:       gitlab.String("feature"),
+				TargetBranch:       gitlab.String("main"),
+				RemoveSourceBranch: gitlab.Bool(false),
+				Squash:             gitlab.Bool(false),
+			},
+		},
+		{
+			name:     "labels",
+			opt:      &CreateUpdateOption{Labels: []string{"bug", "+doing", "-triage"}},
+			upstream: &mergeTarget{defaultBranch: "main"},
+			want: &gitlab.CreateMergeRequestOptions{
+				Title:              gitlab.String("title"),
+				Description:        gitlab.String("message"),
+				SourceBranch:       gitlab.String("feature"),
+				TargetBranch:       gitlab.String("main"),
+				Labels:             &gitlab.Labels{"bug", "doing"},
+				RemoveSourceBranch: gitlab.Bool(false),
+				Squash:             gitlab.Bool(false),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := makeCreateMergeRequestOption(tt.opt, "title", "message", "feature", pInfo, tt.upstream)
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("Invalid result: (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_prefillTitleAndDesc(t *testing.T) {
+	commits := []*git.Commit{
+		&git.Commit{Hash: "abc1234", Subject: "Add feature", Body: "Feature body"},
+		&git.Commit{Hash: "def5678", Subject: "Fix typo"},
+	}
+	tests := []struct {
+		name      string
+		opt       *CreateUpdateOption
+		template  string
+		commits   []*git.Commit
+		wantTitle string
+		wantDesc  string
+	}{
+		{
+			name:      "no commits",
+			opt:       &CreateUpdateOption{},
+			template:  "template",
+			commits:   nil,
+			wantTitle: "",
+			wantDesc:  "template",
+		},
+		{
+			name:      "single commit",
+			opt:       &CreateUpdateOption{},
+			template:  "template",
+			commits:   commits[:1],
+			wantTitle: "Add feature",
+			wantDesc:  "Feature body\n\ntemplate",
+		},
+		{
+			name:      "single commit with options",
+			opt:       &CreateUpdateOption{Title: "title", Message: "message"},
+			template:  "template",
+			commits:   commits[:1],
+			wantTitle: "title",
+			wantDesc:  "message",
+		},
+		{
+			name:      "several commits",
+			opt:       &CreateUpdateOption{},
+			template:  "template\n",
+			commits:   commits,
+			wantTitle: "",
+			wantDesc: `template
+
+# ------------------------ >8 ------------------------
+# Do not modify or remove the line above.
+# Everything below it will be ignored.
+#
+# 2 commits:
+# abc1234 Add feature
+# def5678 Fix typo
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, desc := prefillTitleAndDesc(tt.opt, tt.template, tt.commits, "#")
+			if title != tt.wantTitle {
+				t.Errorf("prefillTitleAndDesc() title = %q, want %q", title, tt.wantTitle)
+			}
+			if diff := cmp.Diff(desc, tt.wantDesc); diff != "" {
+				t.Errorf("prefillTitleAndDesc() desc (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_createMergeRequest(t *testing.T) {
+	resolver := internal.NewResolver(&api.MockAPIClientFactory{
+		MockGetUserClient: func() api.User {
+			return &api.MockUserClient{
+				MockUsers: func(opt *gitlab.ListUsersOptions) ([]*gitlab.User, error) {
+					ids := map[string]int{"alice": 1, "bob": 2}
+					return []*gitlab.User{&gitlab.User{ID: ids[*opt.Username], Username: *opt.Username}}, nil
+				},
+			}
+		},
+	})
+	client := &api.MockLabMergeRequestClient{
+		MockCreateMergeRequest: func(opt *api.CreateMergeRequestOptions, repositoryName string) (*gitlab.MergeRequest, error) {
+			if diff := cmp.Diff(opt.AssigneeIDs, []int{1}); diff != "" {
+				t.Errorf("invalid assignees (-got +want)\n%s", diff)
+			}
+			if opt.AssigneeID != nil {
+				t.Errorf("assignee id must be replaced by assignees, got %d", *opt.AssigneeID)
+			}
+			if diff := cmp.Diff(opt.ReviewerIDs, []int{2}); diff != "" {
+				t.Errorf("invalid reviewers (-got +want)\n%s", diff)
+			}
+			return &gitlab.MergeRequest{IID: 12, ProjectID: 1}, nil
+		},
+	}
+	opt := &CreateUpdateOption{
+		Assignees: []string{"alice"},
+		Reviewers: []string{"@bob"},
+	}
+	createOpt := &gitlab.CreateMergeRequestOptions{AssigneeID: gitlab.Int(13)}
+
+	if _, err := createMergeRequest(client, resolver, opt, createOpt, "fork/repo"); err != nil {
+		t.Fatalf("createMergeRequest() error = %v", err)
+	}
+}
@@ -21,11 +21,11 @@ type detailMethod struct {
 
 func (m *detailMethod) Process() (string, error) {
 	// Do get merge request
-	mergeRequest, err := m.mrClient.GetMergeRequest(m.id, m.project)
+	mergeRequest, reviewers, err := m.mrClient.GetMergeRequestWithReviewers(m.id, m.project)
 	if err != nil {
 		return "", err
 	}
-	res := outMergeRequestDetail(mergeRequest)
+	res := outMergeRequestDetail(mergeRequest, reviewers)
 
 	if m.opt.NoComment {
 		return res, nil
@@ -54,11 +54,13 @@ func makeListMergeRequestNotesOptions() *gitlab.ListMergeRequestNotesOptions {
 	}
 }
 
-func outMergeRequestDetail(mergeRequest *gitlab.MergeRequest) string {
+func outMergeRequestDetail(mergeRequest *gitlab.MergeRequest, reviewers []*gitlab.BasicUser) string {
 	base := `%s %s [%s] (created by @%s, %s)
-Assignee: %s
+Assignees: %s
+Reviewers: %s
 Milestone: %s
 Labels: %s
+Time: %s
 
 %s`
 
@@ -71,6 +73,11 @@ Labels: %s
 		stateColor = color.New(color.FgRed).SprintFunc()
 	}
 
+	state := stateColor(mergeRequest.State)
+	if mergeRequest.WorkInProgress {
+		state = state + ", " + color.New(color.FgMagenta).Sprint("draft")
+	}
+
 	milestone := ""
 	if mergeRequest.Milestone != nil {
 		milestone = mergeRequest.Milestone.Title
@@ -79,17 +86,36 @@ Labels: %s
 	detial := fmt.Sprintf(base,
 		yellow(mergeRequest.IID),
 		cyan(mergeRequest.Title),
-		stateColor(mergeRequest.State),
+		state,
 		mergeRequest.Author.Name,
 		mergeRequest.CreatedAt.String(),
-		mergeRequest.Assignee.Name,
+		strings.Join(assigneeNames(mergeRequest), ", "),
+		strings.Join(names(reviewers), ", "),
 		milestone,
 		strings.Join(mergeRequest.Labels, ", "),
+		internal.TimeStatsOutput(mergeRequest.TimeStats),
 		internal.SweepMarkdownComment(mergeRequest.Description),
 	)
 	return detial
 }
 
+// assigneeNames returns the names of every assignee.
+// The old GitLab without multiple assignees returns only the assignee.
+func assigneeNames(mergeRequest *gitlab.MergeRequest) []string {
+	if len(mergeRequest.Assignees) == 0 && mergeRequest.Assignee != nil {
+		return []string{mergeRequest.Assignee.Name}
+	}
+	return names(mergeRequest.Assignees)
+}
+
+func names(users []*gitlab.BasicUser) []string {
+	names := []string{}
+	for _, user := range users {
+		names = append(names, user.Name)
+	}
+	return names
+}
+
 func noteOutput(note *gitlab.Note) string {
 	base := `
 %s (created by @%s, %s)
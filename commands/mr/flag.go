@@ -2,10 +2,13 @@ package mr
 
 import (
 	"fmt"
+	"strings"
 
 	flags "github.com/jessevdk/go-flags"
 	"github.com/lighttiger2505/lab/commands/internal"
+	"github.com/lighttiger2505/lab/internal/api"
 	"github.com/lighttiger2505/lab/internal/config"
+	gitlab "github.com/xanzy/go-gitlab"
 )
 
 type Option struct {
@@ -13,21 +16,35 @@ type Option struct {
 	CreateUpdateOption   *CreateUpdateOption            `group:"Create, Update Options"`
 	ListOption           *ListOption                    `group:"List Options"`
 	ShowOption           *ShowOption                    `group:"Show Options"`
+	StateOption          *internal.StateOption          `group:"State Options"`
+	TimeTrackingOption   *internal.TimeTrackingOption   `group:"Time Tracking Options"`
+	SubscriptionOption   *internal.SubscriptionOption   `group:"Subscription Options"`
 	BrowseOption         *internal.BrowseOption         `group:"Browse Options"`
 }
 
 type CreateUpdateOption struct {
-	Edit               bool   `short:"e" long:"edit" description:"Edit the merge request on editor. Start the editor with the contents in the given title and message options."`
-	Title              string `short:"i" long:"title" value-name:"<title>" description:"The title of an merge request"`
-	Message            string `short:"m" long:"message" value-name:"<message>" description:"The message of an merge request"`
-	Template           string `short:"p" long:"template" value-name:"<merge request template>" description:"Start the editor with file using merge request template"`
-	SourceBranch       string `long:"source" value-name:"<source branch>" description:"The source branch"`
-	TargetBranch       string `long:"target" value-name:"<target branch>" default:"master" default-mask:"master" description:"The target branch"`
-	StateEvent         string `long:"state-event" value-name:"<state>" description:"Change the status. \"opened\", \"closed\""`
-	AssigneeID         int    `long:"cu-assignee-id" value-name:"<assignee id>" description:"The ID of the user to assign the merge request to. If default_assignee_id is set in config, it is automatically entered"`
-	MilestoneID        int    `long:"cu-milestone-id" value-name:"<milestone id>" description:"The global ID of a milestone to assign the merge request to. "`
-	RemoveSourceBranch string `long:"remove-source-branch" value-name:"<true/false>" description:"Merge request should remove the source branch when merging"`
-	Squash             string `long:"squash" value-name:"<true/false>" description:"Squash commits into a single commit when merging"`
+	Edit               bool     `short:"e" long:"edit" description:"Edit the merge request on editor. Start the editor with the contents in the given title and message options."`
+	Title              string   `short:"i" long:"title" value-name:"<title>" description:"The title of an merge request"`
+	Message            string   `short:"m" long:"message" value-name:"<message>" description:"The message of an merge request"`
+	Template           string   `short:"p" long:"template" value-name:"<merge request template>" description:"Start the editor with file using merge request template"`
+	SourceBranch       string   `long:"source" value-name:"<source branch>" description:"The source branch"`
+	TargetBranch       string   `long:"target" value-name:"<target branch>" description:"The target branch. Defaults to target_branch in config, or the default branch of the project"`
+	StateEvent         string   `long:"state-event" value-name:"<state>" description:"Change the status. \"close\", \"reopen\". Same as --close and --reopen"`
+	Assignee           string   `long:"cu-assignee-id" value-name:"<assignee>" description:"The ID or @username of the user to assign the merge request to. If default_assignee_id is set in config, it is automatically entered"`
+	Milestone          string   `long:"cu-milestone-id" value-name:"<milestone>" description:"The title, %IID or global ID of a milestone to assign the merge request to. "`
+	RemoveSourceBranch string   `long:"remove-source-branch" value-name:"<true/false>" description:"Merge request should remove the source branch when merging"`
+	Squash             string   `long:"squash" value-name:"<true/false>" description:"Squash commits into a single commit when merging"`
+	Upstream           bool     `long:"upstream" description:"Create the merge request to the upstream project of the fork. Enabled automatically when the project is a fork"`
+	NoUpstream         bool     `long:"no-upstream" description:"Create the merge request to the fork itself"`
+	Push               bool     `long:"push" description:"Push the source branch with upstream tracking when it is not pushed or behind the local branch"`
+	Draft              bool     `long:"draft" description:"Mark the merge request as a draft by the \"Draft:\" title prefix. On listing, print only draft merge requests"`
+	Ready              bool     `long:"ready" description:"Remove the \"Draft:\" or \"WIP:\" title prefix of the merge request"`
+	Assignees          []string `long:"assignee" value-name:"<username>" description:"The username to assign the merge request to. Repeatable. \"name\" replaces the assignees, \"+name\" adds and \"-name\" (--assignee=-name) removes"`
+	Reviewers          []string `long:"reviewer" value-name:"<username>" description:"The username to request the review. Repeatable. \"name\" replaces the reviewers, \"+name\" adds and \"-name\" (--reviewer=-name) removes"`
+	Labels             []string `long:"label" value-name:"<label name>" description:"The label of the merge request. Repeatable. Added to default_labels on creating. \"name\" replaces the labels on updating, \"+name\" adds and \"-name\" (--label=-name) removes"`
+	// Resolved from Assignee and Milestone by resolve
+	AssigneeID  int `no-flag:"true"`
+	MilestoneID int `no-flag:"true"`
 }
 
 func (o *CreateUpdateOption) hasEdit() bool {
@@ -39,7 +56,11 @@ func (o *CreateUpdateOption) hasEdit() bool {
 
 func (o *CreateUpdateOption) hasCreate() bool {
 	if o.Title != "" ||
+		o.Assignee != "" ||
+		o.Milestone != "" ||
 		o.AssigneeID != 0 ||
+		len(o.Assignees) > 0 ||
+		len(o.Reviewers) > 0 ||
 		o.MilestoneID != 0 {
 		return true
 	}
@@ -50,15 +71,54 @@ func (o *CreateUpdateOption) hasUpdate() bool {
 	if o.Title != "" ||
 		o.Message != "" ||
 		o.StateEvent != "" ||
+		o.Assignee != "" ||
+		o.Milestone != "" ||
+		len(o.Labels) > 0 ||
 		o.AssigneeID != 0 ||
+		len(o.Assignees) > 0 ||
+		len(o.Reviewers) > 0 ||
 		o.MilestoneID != 0 ||
+		o.TargetBranch != "" ||
 		o.RemoveSourceBranch != "" ||
-		o.Squash != "" {
+		o.Squash != "" ||
+		o.Draft ||
+		o.Ready {
 		return true
 	}
 	return false
 }
 
+// resolve converts the assignee and milestone to the IDs, and the labels to the names in the project.
+func (o *CreateUpdateOption) resolve(resolver *internal.Resolver, project string) error {
+	if o.Assignee != "" {
+		id, err := resolver.UserID(o.Assignee)
+		if err != nil {
+			return err
+		}
+		o.AssigneeID = id
+	}
+	if o.Milestone != "" {
+		id, err := resolver.MilestoneID(project, o.Milestone)
+		if err != nil {
+			return err
+		}
+		o.MilestoneID = id
+	}
+	if len(o.Labels) > 0 {
+		labels, err := resolver.LabelChangeValues(project, o.Labels)
+		if err != nil {
+			return err
+		}
+		o.Labels = labels
+	}
+	return nil
+}
+
+// getCreateLabels returns the labels of a new merge request, that are default_labels changed by --label.
+func (o *CreateUpdateOption) getCreateLabels(defaults []string) []string {
+	return internal.ParseLabelChange(o.Labels).AddTo(defaults)
+}
+
 func (o *CreateUpdateOption) getAssigneeID(profile *config.Profile) int {
 	if o.AssigneeID != 0 {
 		return o.AssigneeID
@@ -69,7 +129,61 @@ func (o *CreateUpdateOption) getAssigneeID(profile *config.Profile) int {
 	return 0
 }
 
+// getAssigneeIDs returns the IDs of the assignees changed from the current assignees by --assignee.
+// It returns nil when --assignee is not given.
+func (o *CreateUpdateOption) getAssigneeIDs(resolver *internal.Resolver, current []*gitlab.BasicUser) ([]int, error) {
+	if len(o.Assignees) == 0 {
+		return nil, nil
+	}
+	return resolver.ChangedUserIDs(usernames(current), o.Assignees)
+}
+
+// getReviewerIDs returns the IDs of the reviewers changed from the current reviewers by --reviewer.
+// It returns nil when --reviewer is not given.
+func (o *CreateUpdateOption) getReviewerIDs(resolver *internal.Resolver, current []*gitlab.BasicUser) ([]int, error) {
+	if len(o.Reviewers) == 0 {
+		return nil, nil
+	}
+	return resolver.ChangedUserIDs(usernames(current), o.Reviewers)
+}
+
+func usernames(users []*gitlab.BasicUser) []string {
+	names := []string{}
+	for _, user := range users {
+		names = append(names, user.Username)
+	}
+	return names
+}
+
+// getTargetBranch returns the option value, or target_branch in config, or the default branch of the target project
+func (o *CreateUpdateOption) getTargetBranch(profile *config.Profile, defaultBranch string) string {
+	if o.TargetBranch != "" {
+		return o.TargetBranch
+	}
+	if profile.TargetBranch != "" {
+		return profile.TargetBranch
+	}
+	if defaultBranch != "" {
+		return defaultBranch
+	}
+	return api.DefaultBranchFallback
+}
+
+func (o *CreateUpdateOption) getTemplate(profile *config.Profile) string {
+	if o.Template != "" {
+		return o.Template
+	}
+	return profile.MRTemplate
+}
+
 func (o *CreateUpdateOption) isValid() error {
+	if o.Upstream && o.NoUpstream {
+		return fmt.Errorf("Cannot specify both --upstream and --no-upstream")
+	}
+	if o.Draft && o.Ready {
+		return fmt.Errorf("Cannot specify both --draft and --ready")
+	}
+
 	if o.Squash != "" {
 		if o.Squash != "true" && o.Squash != "false" {
 			return fmt.Errorf("Invalid option value, %v", o.Squash)
@@ -84,6 +198,49 @@ func (o *CreateUpdateOption) isValid() error {
 	return nil
 }
 
+// getRemoveSourceBranch returns the option value, or remove_source_branch in config when not specified
+func (o *CreateUpdateOption) getRemoveSourceBranch(profile *config.Profile) bool {
+	ok, flag := o.RemoveSourceBranchFlag()
+	if !ok && profile.RemoveSourceBranch != nil {
+		return *profile.RemoveSourceBranch
+	}
+	return flag
+}
+
+// getSquash returns the option value, or squash in config when not specified
+func (o *CreateUpdateOption) getSquash(profile *config.Profile) bool {
+	ok, flag := o.SquashFlag()
+	if !ok && profile.Squash != nil {
+		return *profile.Squash
+	}
+	return flag
+}
+
+// draftPrefixes are the title prefixes that GitLab treats as a draft, in lower case.
+var draftPrefixes = []string{"draft:", "wip:", "[draft]", "[wip]", "(draft)"}
+
+// trimDraftPrefix removes the draft prefix of the title.
+func trimDraftPrefix(title string) string {
+	trimmed := strings.TrimSpace(title)
+	for _, prefix := range draftPrefixes {
+		if strings.HasPrefix(strings.ToLower(trimmed), prefix) {
+			return strings.TrimSpace(trimmed[len(prefix):])
+		}
+	}
+	return title
+}
+
+// applyDraft adds or removes the draft prefix of the title by --draft and --ready.
+func (o *CreateUpdateOption) applyDraft(title string) string {
+	if o.Draft {
+		return "Draft: " + trimDraftPrefix(title)
+	}
+	if o.Ready {
+		return trimDraftPrefix(title)
+	}
+	return title
+}
+
 func (o *CreateUpdateOption) RemoveSourceBranchFlag() (bool, bool) {
 	switch o.RemoveSourceBranch {
 	case "true":
@@ -114,14 +271,55 @@ type ListOption struct {
 	Sort       string `long:"sort"  value-name:"<sort>" default:"desc" default-mask:"desc" description:"Print merge request ordered in \"asc\" or \"desc\" order."`
 	Search     string `short:"s" long:"search"  value-name:"<search word>" description:"Search merge request against their title and description."`
 	Milestone  string `long:"milestone"  value-name:"<milestone>" description:"Print merge requests for a specific milestone. "`
-	AuthorID   int    `long:"author-id"  value-name:"<auther id>" description:"Print merge requests created by the given user id"`
-	AssigneeID int    `long:"assignee-id"  value-name:"<assignee id>" description:"Print merge requests assigned to the given user id."`
+	Author     string `long:"author-id"  value-name:"<author>" description:"Print merge requests created by the given user id or @username"`
+	Assignee   string `long:"assignee-id"  value-name:"<assignee>" description:"Print merge requests assigned to the given user id or @username."`
 	Opened     bool   `short:"O" long:"opened" description:"Shorthand of the state option for \"--state=opened\"."`
 	Closed     bool   `short:"C" long:"closed" description:"Shorthand of the state option for \"--state=closed\"."`
 	Merged     bool   `short:"g" long:"merged" description:"Shorthand of the state option for \"--state=merged\"."`
 	CreatedMe  bool   `short:"r" long:"created-me" description:"Shorthand of the scope option for \"--scope=created-by-me\"."`
 	AssignedMe bool   `short:"a" long:"assigned-me" description:"Shorthand of the scope option for \"--scope=assigned-by-me\"."`
 	AllProject bool   `short:"A" long:"all-project" description:"Print the merge request of all projects"`
+	Group      string `long:"group" value-name:"<group>" description:"Print the merge request of all projects in the given group"`
+	NoDraft    bool   `long:"no-draft" description:"Print only merge requests that are not drafts"`
+	// Draft is --draft of CreateUpdateOption, because an option cannot be defined twice
+	Draft bool `no-flag:"true"`
+	// Resolved from Author and Assignee by resolve
+	AuthorID   int `no-flag:"true"`
+	AssigneeID int `no-flag:"true"`
+}
+
+func (l *ListOption) isValid() error {
+	if l.AllProject && l.Group != "" {
+		return fmt.Errorf("Cannot specify both --all-project and --group")
+	}
+	return nil
+}
+
+// resolve converts the author and assignee to the IDs, and checks the milestone of the project.
+// The milestone of the group or all projects is passed through, because it may not be in the project.
+func (l *ListOption) resolve(resolver *internal.Resolver, project string) error {
+	if l.Author != "" {
+		id, err := resolver.UserID(l.Author)
+		if err != nil {
+			return err
+		}
+		l.AuthorID = id
+	}
+	if l.Assignee != "" {
+		id, err := resolver.UserID(l.Assignee)
+		if err != nil {
+			return err
+		}
+		l.AssigneeID = id
+	}
+	if l.Milestone != "" && !l.AllProject && l.Group == "" && !internal.IsMilestoneWildcard(l.Milestone) {
+		title, err := resolver.MilestoneTitle(project, l.Milestone)
+		if err != nil {
+			return err
+		}
+		l.Milestone = title
+	}
+	return nil
 }
 
 func (l *ListOption) getState() string {
@@ -137,6 +335,17 @@ func (l *ListOption) getState() string {
 	return l.State
 }
 
+// getWIP returns the "wip" filter of the merge request list, or nil to list both.
+func (l *ListOption) getWIP() *string {
+	if l.Draft && !l.NoDraft {
+		return gitlab.String("yes")
+	}
+	if l.NoDraft && !l.Draft {
+		return gitlab.String("no")
+	}
+	return nil
+}
+
 func (l *ListOption) getScope() string {
 	if l.CreatedMe {
 		return "created-by-me"
@@ -159,6 +368,9 @@ func newOptionParser(opt *Option) *flags.Parser {
 	opt.ProjectProfileOption = &internal.ProjectProfileOption{}
 	opt.CreateUpdateOption = &CreateUpdateOption{}
 	opt.ListOption = &ListOption{}
+	opt.StateOption = &internal.StateOption{}
+	opt.TimeTrackingOption = &internal.TimeTrackingOption{}
+	opt.SubscriptionOption = &internal.SubscriptionOption{}
 	opt.BrowseOption = &internal.BrowseOption{}
 	parser := flags.NewParser(opt, flags.HelpFlag|flags.PassDoubleDash)
 	parser.Usage = `merge-request - Create and Edit, List, Browse a merge request
@@ -166,17 +378,31 @@ func newOptionParser(opt *Option) *flags.Parser {
 Synopsis:
   # List merge request
   lab merge-request [-n <num>] [--state=<state> | -o | -c] [--scope=<scope> | -r | -a] [-s <search word>]
-                    [--milestone=<milestone>] [--author-id=<author id>] [--assignee-id=<assignee id>]
-                    [--orderby <orderby>] [--sort <sort>] [-A]
+                    [--milestone=<milestone>] [--author-id=<author>] [--assignee-id=<assignee>]
+                    [--orderby <orderby>] [--sort <sort>] [-A | --group <group>] [--draft | --no-draft]
 
   # Create merge request
   lab merge-request -e | -i <title> [-m <message>] 
-                    [--cu-assignee-id=<assignee id>] [--cu-milestone-id=<milestone id>]
+                    [--cu-assignee-id=<assignee>] [--cu-milestone-id=<milestone>] [--label=<label name>...]
+                    [--upstream | --no-upstream] [--push] [--draft]
+                    [--assignee=<username>...] [--reviewer=<username>...]
 
   # Update merge request
   lab merge-request <merge request id> [-e] [-i <title>] [-m <message>] 
                                        [--state-event=<state>]
-                                       [--cu-assignee-id=<assignee id>] [--cu-milestone-id=<milestone id>]
+                                       [--cu-assignee-id=<assignee>] [--cu-milestone-id=<milestone>] [--label=<label name>...]
+                                       [--draft | --ready]
+                                       [--assignee=[+|-]<username>...] [--reviewer=[+|-]<username>...]
+
+  # Close, reopen, lock or delete merge request
+  lab merge-request <merge request id> [--close | --reopen] [--lock | --unlock]
+  lab merge-request <merge request id> --delete [--yes]
+
+  # Track time of merge request
+  lab merge-request <merge request id> [--estimate=<duration>] [--spend=<duration> [--summary=<summary>]] [--reset-spent]
+
+  # Subscribe merge request
+  lab merge-request <merge request id> --subscribe | --unsubscribe
 
   # Show merge request
   lab merge-request <merge request id> [--no-comment]
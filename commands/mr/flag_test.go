@@ -0,0 +1,49 @@
+package mr
+
+import "testing"
+
+func TestCreateUpdateOption_applyDraft(t *testing.T) {
+	tests := []struct {
+		name  string
+		opt   *CreateUpdateOption
+		title string
+		want  string
+	}{
+		{name: "no option", opt: &CreateUpdateOption{}, title: "WIP: title", want: "WIP: title"},
+		{name: "draft", opt: &CreateUpdateOption{Draft: true}, title: "title", want: "Draft: title"},
+		{name: "draft already wip", opt: &CreateUpdateOption{Draft: true}, title: "WIP: title", want: "Draft: title"},
+		{name: "ready draft", opt: &CreateUpdateOption{Ready: true}, title: "Draft: title", want: "title"},
+		{name: "ready wip", opt: &CreateUpdateOption{Ready: true}, title: "[WIP] title", want: "title"},
+		{name: "ready not draft", opt: &CreateUpdateOption{Ready: true}, title: "Drafting title", want: "Drafting title"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opt.applyDraft(tt.title); got != tt.want {
+				t.Errorf("CreateUpdateOption.applyDraft() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListOption_getWIP(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  *ListOption
+		want string
+	}{
+		{name: "both", opt: &ListOption{}, want: ""},
+		{name: "draft", opt: &ListOption{Draft: true}, want: "yes"},
+		{name: "no draft", opt: &ListOption{NoDraft: true}, want: "no"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ""
+			if wip := tt.opt.getWIP(); wip != nil {
+				got = *wip
+			}
+			if got != tt.want {
+				t.Errorf("ListOption.getWIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
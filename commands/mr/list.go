@@ -49,6 +49,25 @@ func (m *listAllMethod) Process() (string, error) {
 	return columnize.SimpleFormat(outputs), nil
 }
 
+type listGroupMethod struct {
+	internal.Method
+	client api.MergeRequest
+	opt    *ListOption
+}
+
+func (m *listGroupMethod) Process() (string, error) {
+	mergeRequests, err := m.client.GetGroupMergeRequests(
+		makeGroupMergeRequestOption(m.opt),
+		m.opt.Group,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	outputs := outMergeRequest(mergeRequests)
+	return columnize.SimpleFormat(outputs), nil
+}
+
 func makeMergeRequestOption(listMergeRequestsOption *ListOption) *gitlab.ListMergeRequestsOptions {
 	listOption := &gitlab.ListOptions{
 		Page:    1,
@@ -61,6 +80,7 @@ func makeMergeRequestOption(listMergeRequestsOption *ListOption) *gitlab.ListMer
 		OrderBy:     gitlab.String(listMergeRequestsOption.OrderBy),
 		Sort:        gitlab.String(listMergeRequestsOption.Sort),
 		Search:      gitlab.String(listMergeRequestsOption.Search),
+		WIP:         listMergeRequestsOption.getWIP(),
 		ListOptions: *listOption,
 	}
 
@@ -87,6 +107,7 @@ func makeProjectMergeRequestOption(listMergeRequestsOption *ListOption) *gitlab.
 		OrderBy:     gitlab.String(listMergeRequestsOption.OrderBy),
 		Sort:        gitlab.String(listMergeRequestsOption.Sort),
 		Search:      gitlab.String(listMergeRequestsOption.Search),
+		WIP:         listMergeRequestsOption.getWIP(),
 		ListOptions: *listOption,
 	}
 
@@ -102,13 +123,42 @@ func makeProjectMergeRequestOption(listMergeRequestsOption *ListOption) *gitlab.
 	return listMergeRequestsOptions
 }
 
+func makeGroupMergeRequestOption(listMergeRequestsOption *ListOption) *api.ListGroupMergeRequestsOptions {
+	listOption := &gitlab.ListOptions{
+		Page:    1,
+		PerPage: listMergeRequestsOption.Num,
+	}
+	listGroupMergeRequestsOptions := &api.ListGroupMergeRequestsOptions{
+		ListGroupMergeRequestsOptions: gitlab.ListGroupMergeRequestsOptions{
+			State:       gitlab.String(listMergeRequestsOption.getState()),
+			Scope:       gitlab.String(listMergeRequestsOption.getScope()),
+			OrderBy:     gitlab.String(listMergeRequestsOption.OrderBy),
+			Sort:        gitlab.String(listMergeRequestsOption.Sort),
+			Search:      gitlab.String(listMergeRequestsOption.Search),
+			ListOptions: *listOption,
+		},
+		WIP: listMergeRequestsOption.getWIP(),
+	}
+
+	if listMergeRequestsOption.Milestone != "" {
+		listGroupMergeRequestsOptions.Milestone = gitlab.String(listMergeRequestsOption.Milestone)
+	}
+	if listMergeRequestsOption.AuthorID != 0 {
+		listGroupMergeRequestsOptions.AuthorID = gitlab.Int(listMergeRequestsOption.AuthorID)
+	}
+	if listMergeRequestsOption.AssigneeID != 0 {
+		listGroupMergeRequestsOptions.AssigneeID = gitlab.Int(listMergeRequestsOption.AssigneeID)
+	}
+	return listGroupMergeRequestsOptions
+}
+
 func outProjectMergeRequest(mergeRequsets []*gitlab.MergeRequest) []string {
 	yellow := color.New(color.FgYellow).SprintFunc()
 	outputs := []string{}
 	for _, mergeRequest := range mergeRequsets {
 		output := strings.Join([]string{
 			yellow(mergeRequest.IID),
-			mergeRequest.Title,
+			titleOutput(mergeRequest),
 		}, "|")
 		outputs = append(outputs, output)
 	}
@@ -123,9 +173,18 @@ func outMergeRequest(mergeRequsets []*gitlab.MergeRequest) []string {
 		output := strings.Join([]string{
 			cyan(internal.ParceRepositoryFullName(mergeRequest.WebURL)),
 			yellow(mergeRequest.IID),
-			mergeRequest.Title,
+			titleOutput(mergeRequest),
 		}, "|")
 		outputs = append(outputs, output)
 	}
 	return outputs
 }
+
+// titleOutput marks the draft merge request instead of the title prefix.
+func titleOutput(mergeRequest *gitlab.MergeRequest) string {
+	if !mergeRequest.WorkInProgress {
+		return mergeRequest.Title
+	}
+	magenta := color.New(color.FgMagenta).SprintFunc()
+	return magenta("[draft]") + " " + trimDraftPrefix(mergeRequest.Title)
+}
@@ -58,7 +58,7 @@ func (c *MergeRequestCommand) Run(args []string) int {
 		return ExitCodeError
 	}
 
-	if err := c.ClientFactory.Init(pInfo.ApiUrl(), pInfo.Token); err != nil {
+	if err := c.ClientFactory.Init(pInfo.ApiUrl(), pInfo.Token, pInfo.TokenType); err != nil {
 		c.UI.Error(err.Error())
 		return ExitCodeError
 	}
@@ -87,6 +87,7 @@ func (c *MergeRequestCommand) getMethod(opt Option, args []string, pInfo *gituti
 	listOption := opt.ListOption
 	browseOption := opt.BrowseOption
 	showOption := opt.ShowOption
+	listOption.Draft = createUpdateOption.Draft
 
 	mrClient := clientFactory.GetMergeRequestClient()
 	repositoryClient := clientFactory.GetRepositoryClient()
@@ -100,6 +101,32 @@ func (c *MergeRequestCommand) getMethod(opt Option, args []string, pInfo *gituti
 	if err := createUpdateOption.isValid(); err != nil {
 		return nil, err
 	}
+	if err := listOption.isValid(); err != nil {
+		return nil, err
+	}
+	if err := validStateOption(opt, iid); err != nil {
+		return nil, err
+	}
+	if err := opt.TimeTrackingOption.IsValid(); err != nil {
+		return nil, err
+	}
+	if opt.TimeTrackingOption.HasTimeTracking() && iid == 0 {
+		return nil, fmt.Errorf("Invalid args, please input merge request id to track the time")
+	}
+	if err := opt.SubscriptionOption.IsValid(); err != nil {
+		return nil, err
+	}
+	if opt.SubscriptionOption.HasSubscription() && iid == 0 {
+		return nil, fmt.Errorf("Invalid args, please input merge request id to subscribe")
+	}
+
+	resolver := internal.NewResolver(clientFactory)
+	if err := createUpdateOption.resolve(resolver, pInfo.Project); err != nil {
+		return nil, err
+	}
+	if err := listOption.resolve(resolver, pInfo.Project); err != nil {
+		return nil, err
+	}
 
 	if browseOption.HasBrowse() {
 		return &internal.BrowseMethod{
@@ -113,22 +140,49 @@ func (c *MergeRequestCommand) getMethod(opt Option, args []string, pInfo *gituti
 
 	// Case of getting Merge Request id
 	if len(args) > 0 {
-		if createUpdateOption.hasEdit() {
-			return &updateOnEditorMethod{
-				client:   mrClient,
-				opt:      createUpdateOption,
-				project:  pInfo.Project,
-				id:       iid,
-				editFunc: c.EditFunc,
+		if opt.StateOption.Delete {
+			target := fmt.Sprintf("merge request !%d of %s", iid, pInfo.Project)
+			if err := internal.ConfirmDelete(c.UI, target, opt.StateOption.Yes); err != nil {
+				return nil, err
+			}
+			return &deleteMethod{
+				client:  mrClient,
+				project: pInfo.Project,
+				id:      iid,
 			}, nil
 		}
-		if createUpdateOption.hasUpdate() {
-			return &updateMethod{
+
+		method := c.newUpdateMethod(createUpdateOption, pInfo, iid, mrClient, resolver)
+		if opt.StateOption.HasState() {
+			method = &stateMethod{
+				method:  method,
 				client:  mrClient,
-				opt:     createUpdateOption,
+				opt:     opt.StateOption,
 				project: pInfo.Project,
 				id:      iid,
-			}, nil
+			}
+		}
+		if opt.TimeTrackingOption.HasTimeTracking() {
+			method = &internal.TimeTrackingMethod{
+				Method:  method,
+				Client:  clientFactory.GetTimeStatsClient(),
+				Opt:     opt.TimeTrackingOption,
+				Entity:  api.MergeRequestEntity,
+				Project: pInfo.Project,
+				IID:     iid,
+			}
+		}
+		if opt.SubscriptionOption.HasSubscription() {
+			method = &subscribeMethod{
+				method:  method,
+				client:  mrClient,
+				opt:     opt.SubscriptionOption,
+				project: pInfo.Project,
+				id:      iid,
+			}
+		}
+		if method != nil {
+			return method, nil
 		}
 
 		return &detailMethod{
@@ -144,7 +198,12 @@ func (c *MergeRequestCommand) getMethod(opt Option, args []string, pInfo *gituti
 	if createUpdateOption.hasEdit() {
 		return &createOnEditorMethod{
 			client:           mrClient,
+			resolver:         resolver,
 			repositoryClient: repositoryClient,
+			projectClient:    clientFactory.GetProjectClient(),
+			branchClient:     clientFactory.GetBranchClient(),
+			gitClient:        c.GitClient,
+			pusher:           c.newSourceBranchPusher(createUpdateOption, pInfo, clientFactory),
 			opt:              createUpdateOption,
 			pInfo:            pInfo,
 			editFunc:         c.EditFunc,
@@ -153,12 +212,21 @@ func (c *MergeRequestCommand) getMethod(opt Option, args []string, pInfo *gituti
 	}
 	if createUpdateOption.hasCreate() {
 		return &createMethod{
-			client: mrClient,
-			opt:    createUpdateOption,
-			pInfo:  pInfo,
+			client:        mrClient,
+			resolver:      resolver,
+			projectClient: clientFactory.GetProjectClient(),
+			pusher:        c.newSourceBranchPusher(createUpdateOption, pInfo, clientFactory),
+			opt:           createUpdateOption,
+			pInfo:         pInfo,
 		}, nil
 	}
 
+	if listOption.Group != "" {
+		return &listGroupMethod{
+			client: mrClient,
+			opt:    listOption,
+		}, nil
+	}
 	if listOption.AllProject {
 		return &listAllMethod{
 			client: mrClient,
@@ -173,6 +241,63 @@ func (c *MergeRequestCommand) getMethod(opt Option, args []string, pInfo *gituti
 	}, nil
 }
 
+func (c *MergeRequestCommand) newSourceBranchPusher(opt *CreateUpdateOption, pInfo *gitutil.GitLabProjectInfo, clientFactory api.APIClientFactory) *sourceBranchPusher {
+	return &sourceBranchPusher{
+		ui:           c.UI,
+		gitClient:    c.GitClient,
+		branchClient: clientFactory.GetBranchClient(),
+		push:         opt.Push,
+		pInfo:        pInfo,
+	}
+}
+
+// newUpdateMethod returns nil when the merge request is not updated.
+func (c *MergeRequestCommand) newUpdateMethod(opt *CreateUpdateOption, pInfo *gitutil.GitLabProjectInfo, iid int, client api.MergeRequest, resolver *internal.Resolver) internal.Method {
+	if opt.hasEdit() {
+		return &updateOnEditorMethod{
+			client:   client,
+			resolver: resolver,
+			opt:      opt,
+			project:  pInfo.Project,
+			id:       iid,
+			editFunc: c.EditFunc,
+		}
+	}
+	if opt.hasUpdate() {
+		return &updateMethod{
+			client:   client,
+			resolver: resolver,
+			opt:      opt,
+			project:  pInfo.Project,
+			id:       iid,
+		}
+	}
+	return nil
+}
+
+// validStateOption checks the state actions, and --delete that cannot be combined with the other changes.
+func validStateOption(opt Option, iid int) error {
+	if err := opt.StateOption.IsValid(); err != nil {
+		return err
+	}
+	if !opt.StateOption.HasState() {
+		return nil
+	}
+	if iid == 0 {
+		return fmt.Errorf("Invalid args, please input merge request id to change the state")
+	}
+	if opt.CreateUpdateOption.StateEvent != "" {
+		return fmt.Errorf("Cannot specify both --state-event and the state options")
+	}
+	if opt.StateOption.Delete && (opt.CreateUpdateOption.hasUpdate() ||
+		opt.CreateUpdateOption.hasEdit() ||
+		opt.TimeTrackingOption.HasTimeTracking() ||
+		opt.SubscriptionOption.HasSubscription()) {
+		return fmt.Errorf("Cannot specify --delete with the other changes of the merge request")
+	}
+	return nil
+}
+
 func validMergeRequestIID(args []string) (int, error) {
 	if len(args) < 1 {
 		return 0, nil
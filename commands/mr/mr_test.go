@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/lighttiger2505/lab/git"
 	"github.com/lighttiger2505/lab/internal/api"
 	"github.com/lighttiger2505/lab/internal/gitutil"
 	"github.com/lighttiger2505/lab/internal/ui"
@@ -16,22 +17,10 @@ var updatedAt, _ = time.Parse("2006-01-02", "2018-03-14")
 var mergeRequest = &gitlab.MergeRequest{
 	IID:   12,
 	Title: "Title12",
-	Assignee: struct {
-		ID        int        `json:"id"`
-		Username  string     `json:"username"`
-		Name      string     `json:"name"`
-		State     string     `json:"state"`
-		CreatedAt *time.Time `json:"created_at"`
-	}{
+	Assignee: &gitlab.BasicUser{
 		Name: "AssigneeName",
 	},
-	Author: struct {
-		ID        int        `json:"id"`
-		Username  string     `json:"username"`
-		Name      string     `json:"name"`
-		State     string     `json:"state"`
-		CreatedAt *time.Time `json:"created_at"`
-	}{
+	Author: &gitlab.BasicUser{
 		Name: "AuthorName",
 	},
 	WebURL:      "http://gitlab.jp/namespace/repo12",
@@ -55,7 +44,7 @@ var mockGitlabMergeRequestClient = &api.MockLabMergeRequestClient{
 	MockGetProjectMargeRequest: func(opt *gitlab.ListProjectMergeRequestsOptions, repositoryName string) ([]*gitlab.MergeRequest, error) {
 		return mergeRequests, nil
 	},
-	MockCreateMergeRequest: func(opt *gitlab.CreateMergeRequestOptions, repositoryName string) (*gitlab.MergeRequest, error) {
+	MockCreateMergeRequest: func(opt *api.CreateMergeRequestOptions, repositoryName string) (*gitlab.MergeRequest, error) {
 		return mergeRequest, nil
 	},
 	MockUpdateMergeRequest: func(opt *gitlab.UpdateMergeRequestOptions, pid int, repositoryName string) (*gitlab.MergeRequest, error) {
@@ -75,6 +64,21 @@ var mockNoteClient = &api.MockNoteClient{
 	},
 }
 
+var mockProjectClient = &api.MockProjectClient{
+	MockGetProject: func(project string) (*gitlab.Project, error) {
+		return &gitlab.Project{ID: 1, DefaultBranch: "master"}, nil
+	},
+	MockDefaultBranch: func(project string) (string, error) {
+		return "master", nil
+	},
+}
+
+var mockBranchClient = &api.MockBranchClient{
+	MockGetBranch: func(project string, branch string) (*gitlab.Branch, error) {
+		return &gitlab.Branch{Name: branch, Commit: &gitlab.Commit{ID: "abcdef"}}, nil
+	},
+}
+
 var mockAPIClientFactory = &api.MockAPIClientFactory{
 	MockGetMergeRequestClient: func() api.MergeRequest {
 		return mockGitlabMergeRequestClient
@@ -85,6 +89,12 @@ var mockAPIClientFactory = &api.MockAPIClientFactory{
 	MockGetNoteClient: func() api.Note {
 		return mockNoteClient
 	},
+	MockGetProjectClient: func() api.Project {
+		return mockProjectClient
+	},
+	MockGetBranchClient: func() api.Branch {
+		return mockBranchClient
+	},
 }
 
 func TestMergeRequestCommandRun_List(t *testing.T) {
@@ -92,6 +102,7 @@ func TestMergeRequestCommandRun_List(t *testing.T) {
 	c := MergeRequestCommand{
 		UI:              mockUI,
 		RemoteCollecter: &gitutil.MockCollecter{},
+		GitClient:       &git.MockClient{},
 		ClientFactory:   mockAPIClientFactory,
 	}
 
@@ -112,6 +123,7 @@ func TestMergeRequestCommandRun_ListAll(t *testing.T) {
 	c := MergeRequestCommand{
 		UI:              mockUI,
 		RemoteCollecter: &gitutil.MockCollecter{},
+		GitClient:       &git.MockClient{},
 		ClientFactory:   mockAPIClientFactory,
 	}
 
@@ -132,6 +144,7 @@ func TestMergeRequestCommandRun_Create(t *testing.T) {
 	c := MergeRequestCommand{
 		UI:              mockUI,
 		RemoteCollecter: &gitutil.MockCollecter{},
+		GitClient:       &git.MockClient{},
 		ClientFactory:   mockAPIClientFactory,
 	}
 
@@ -152,6 +165,7 @@ func TestMergeRequestCommandRun_CreateOnEditor(t *testing.T) {
 	c := MergeRequestCommand{
 		UI:              mockUI,
 		RemoteCollecter: &gitutil.MockCollecter{},
+		GitClient:       &git.MockClient{},
 		ClientFactory:   mockAPIClientFactory,
 		EditFunc: func(program, file string) error {
 			return nil
@@ -175,6 +189,7 @@ func TestMergeRequestCommandRun_Update(t *testing.T) {
 	c := MergeRequestCommand{
 		UI:              mockUI,
 		RemoteCollecter: &gitutil.MockCollecter{},
+		GitClient:       &git.MockClient{},
 		ClientFactory:   mockAPIClientFactory,
 	}
 
@@ -195,6 +210,7 @@ func TestMergeRequestCommandRun_UpdateOnEditor(t *testing.T) {
 	c := MergeRequestCommand{
 		UI:              mockUI,
 		RemoteCollecter: &gitutil.MockCollecter{},
+		GitClient:       &git.MockClient{},
 		ClientFactory:   mockAPIClientFactory,
 		EditFunc: func(program, file string) error {
 			return nil
@@ -218,6 +234,7 @@ func TestMergeRequestCommandRun_Show(t *testing.T) {
 	c := MergeRequestCommand{
 		UI:              mockUI,
 		RemoteCollecter: &gitutil.MockCollecter{},
+		GitClient:       &git.MockClient{},
 		ClientFactory:   mockAPIClientFactory,
 	}
 
@@ -228,9 +245,11 @@ func TestMergeRequestCommandRun_Show(t *testing.T) {
 
 	got := mockUI.Writer.String()
 	want := `12 Title12 [] (created by @AuthorName, 2018-02-14 00:00:00 +0000 UTC)
-Assignee: AssigneeName
+Assignees: AssigneeName
+Reviewers: 
 Milestone: 
 Labels: 
+Time: - spent / - estimated
 
 Description
 `
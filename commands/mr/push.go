@@ -0,0 +1,74 @@
+package mr
+
+import (
+	"fmt"
+
+	"github.com/lighttiger2505/lab/git"
+	"github.com/lighttiger2505/lab/internal/api"
+	"github.com/lighttiger2505/lab/internal/gitutil"
+	"github.com/lighttiger2505/lab/internal/ui"
+)
+
+// sourceBranchPusher makes sure that the source branch is pushed before creating a merge request.
+type sourceBranchPusher struct {
+	ui           ui.UI
+	gitClient    git.Client
+	branchClient api.Branch
+	push         bool
+	pInfo        *gitutil.GitLabProjectInfo
+}
+
+func (p *sourceBranchPusher) ensure(branch string) error {
+	remoteBranch, err := p.branchClient.GetBranch(p.pInfo.Project, branch)
+	if err != nil && err != api.ErrBranchNotFound {
+		return err
+	}
+
+	if remoteBranch == nil {
+		message := fmt.Sprintf("Branch [%s] is not pushed to %s.", branch, p.pInfo.Project)
+		ok, err := p.confirm(message)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("%s Please push it, or use --push", message)
+		}
+		return p.doPush(branch)
+	}
+
+	ahead, err := p.gitClient.CommitsAhead(remoteBranch.Commit.ID, branch)
+	if err != nil {
+		// The remote commit is unknown in the local repository, e.g. pushed from another machine
+		p.ui.Error(fmt.Sprintf("Warning: cannot compare branch [%s] with %s. %s", branch, p.pInfo.Project, err))
+		return nil
+	}
+	if ahead == 0 {
+		return nil
+	}
+
+	message := fmt.Sprintf("%d local commits of branch [%s] are not pushed to %s.", ahead, branch, p.pInfo.Project)
+	ok, err := p.confirm(message)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		p.ui.Error("Warning: " + message)
+		return nil
+	}
+	return p.doPush(branch)
+}
+
+// confirm returns true when --push is given, or the user accepts to push.
+func (p *sourceBranchPusher) confirm(message string) (bool, error) {
+	if p.push {
+		return true, nil
+	}
+	return ui.Confirm(p.ui, message+" Push it?")
+}
+
+func (p *sourceBranchPusher) doPush(branch string) error {
+	if p.pInfo.Remote == "" {
+		return fmt.Errorf("Not found the git remote of %s. Please push branch [%s]", p.pInfo.Project, branch)
+	}
+	return p.gitClient.Push(p.pInfo.Remote, branch)
+}
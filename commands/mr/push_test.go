@@ -0,0 +1,115 @@
+package mr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lighttiger2505/lab/git"
+	"github.com/lighttiger2505/lab/internal/api"
+	"github.com/lighttiger2505/lab/internal/gitutil"
+	"github.com/lighttiger2505/lab/internal/ui"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+func Test_sourceBranchPusher_ensure(t *testing.T) {
+	tests := []struct {
+		name       string
+		push       bool
+		noInput    bool
+		input      string
+		remote     *gitlab.Branch
+		ahead      int
+		aheadErr   error
+		wantPushed bool
+		wantErr    bool
+		wantWarn   string
+	}{
+		{
+			name:   "up to date",
+			remote: &gitlab.Branch{Commit: &gitlab.Commit{ID: "abc"}},
+		},
+		{
+			name:       "missing with push",
+			push:       true,
+			wantPushed: true,
+		},
+		{
+			name:       "missing and accepted",
+			input:      "y\n",
+			wantPushed: true,
+		},
+		{
+			name:    "missing in non-interactive mode",
+			noInput: true,
+			wantErr: true,
+		},
+		{
+			name:       "behind with push",
+			push:       true,
+			remote:     &gitlab.Branch{Commit: &gitlab.Commit{ID: "abc"}},
+			ahead:      2,
+			wantPushed: true,
+		},
+		{
+			name:     "behind and declined",
+			input:    "n\n",
+			remote:   &gitlab.Branch{Commit: &gitlab.Commit{ID: "abc"}},
+			ahead:    2,
+			wantWarn: "Warning: 2 local commits of branch [feature] are not pushed to group/repo.",
+		},
+		{
+			name:     "unknown remote commit",
+			remote:   &gitlab.Branch{Commit: &gitlab.Commit{ID: "abc"}},
+			aheadErr: errors.New("bad revision"),
+			wantWarn: "Warning: cannot compare branch [feature] with group/repo.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pushed := false
+			mockUI := ui.NewMockUi()
+			mockUI.Reader = strings.NewReader(tt.input)
+			var u ui.UI = mockUI
+			if tt.noInput {
+				u = &ui.BasicUi{NoInput: true}
+			}
+			p := &sourceBranchPusher{
+				ui: u,
+				gitClient: &git.MockClient{
+					MockCommitsAhead: func(base, branch string) (int, error) {
+						return tt.ahead, tt.aheadErr
+					},
+					MockPush: func(remote, branch string) error {
+						if remote != "origin" || branch != "feature" {
+							t.Errorf("bad push, %s %s", remote, branch)
+						}
+						pushed = true
+						return nil
+					},
+				},
+				branchClient: &api.MockBranchClient{
+					MockGetBranch: func(project string, branch string) (*gitlab.Branch, error) {
+						if tt.remote == nil {
+							return nil, api.ErrBranchNotFound
+						}
+						return tt.remote, nil
+					},
+				},
+				push:  tt.push,
+				pInfo: &gitutil.GitLabProjectInfo{Project: "group/repo", Remote: "origin"},
+			}
+
+			err := p.ensure("feature")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ensure() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if pushed != tt.wantPushed {
+				t.Errorf("pushed = %v, want %v", pushed, tt.wantPushed)
+			}
+			if got := mockUI.ErrorWriter.String(); !strings.Contains(got, tt.wantWarn) {
+				t.Errorf("warning = %q, want %q", got, tt.wantWarn)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package mr
+
+import (
+	"github.com/lighttiger2505/lab/commands/internal"
+	"github.com/lighttiger2505/lab/internal/api"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+// stateMethod closes, reopens, locks or unlocks the merge request.
+type stateMethod struct {
+	// method runs before changing the state, that is nil when the merge request is not updated.
+	method  internal.Method
+	client  api.MergeRequest
+	opt     *internal.StateOption
+	project string
+	id      int
+}
+
+func (m *stateMethod) Process() (string, error) {
+	res := ""
+	if m.method != nil {
+		out, err := m.method.Process()
+		if err != nil {
+			return "", err
+		}
+		res = out
+	}
+
+	updateOpt := &gitlab.UpdateMergeRequestOptions{
+		DiscussionLocked: m.opt.GetDiscussionLocked(),
+	}
+	if stateEvent := m.opt.GetStateEvent(); stateEvent != "" {
+		updateOpt.StateEvent = gitlab.String(stateEvent)
+	}
+	if _, err := m.client.UpdateMergeRequest(updateOpt, m.id, m.project); err != nil {
+		return "", err
+	}
+	return res, nil
+}
+
+type deleteMethod struct {
+	client  api.MergeRequest
+	project string
+	id      int
+}
+
+func (m *deleteMethod) Process() (string, error) {
+	if err := m.client.DeleteMergeRequest(m.id, m.project); err != nil {
+		return "", err
+	}
+	return "", nil
+}
@@ -0,0 +1,38 @@
+package mr
+
+import (
+	"github.com/lighttiger2505/lab/commands/internal"
+	"github.com/lighttiger2505/lab/internal/api"
+)
+
+type subscribeMethod struct {
+	// method runs before subscribing, that is nil when the merge request is not updated.
+	method  internal.Method
+	client  api.MergeRequest
+	opt     *internal.SubscriptionOption
+	project string
+	id      int
+}
+
+func (m *subscribeMethod) Process() (string, error) {
+	res := ""
+	if m.method != nil {
+		out, err := m.method.Process()
+		if err != nil {
+			return "", err
+		}
+		res = out
+	}
+
+	if m.opt.Subscribe {
+		if err := m.client.SubscribeToMergeRequest(m.id, m.project); err != nil {
+			return "", err
+		}
+	}
+	if m.opt.Unsubscribe {
+		if err := m.client.UnsubscribeFromMergeRequest(m.id, m.project); err != nil {
+			return "", err
+		}
+	}
+	return res, nil
+}
@@ -8,10 +8,11 @@ import (
 
 type updateMethod struct {
 	internal.Method
-	client  api.MergeRequest
-	opt     *CreateUpdateOption
-	project string
-	id      int
+	client   api.MergeRequest
+	resolver *internal.Resolver
+	opt      *CreateUpdateOption
+	project  string
+	id       int
 }
 
 func (m *updateMethod) Process() (string, error) {
@@ -32,13 +33,16 @@ func (m *updateMethod) Process() (string, error) {
 	}
 
 	// Do update merge request
-	_, err = m.client.UpdateMergeRequest(
+	err = updateMergeRequest(
+		m.client,
+		m.resolver,
+		m.opt,
 		makeUpdateMergeRequestOption(m.opt, updatedTitle, updatedMessage),
-		m.id,
+		mergeRequest,
 		m.project,
 	)
 	if err != nil {
-		return "", nil
+		return "", err
 	}
 
 	// Return empty value
@@ -48,6 +52,7 @@ func (m *updateMethod) Process() (string, error) {
 type updateOnEditorMethod struct {
 	internal.Method
 	client   api.MergeRequest
+	resolver *internal.Resolver
 	opt      *CreateUpdateOption
 	project  string
 	id       int
@@ -72,24 +77,66 @@ func (m *updateOnEditorMethod) Process() (string, error) {
 	}
 
 	// Do update merge request
-	_, err = m.client.UpdateMergeRequest(
+	err = updateMergeRequest(
+		m.client,
+		m.resolver,
+		m.opt,
 		makeUpdateMergeRequestOption(m.opt, title, message),
-		m.id,
+		mergeRequest,
 		m.project,
 	)
 	if err != nil {
-		return "", nil
+		return "", err
 	}
 
 	// Return empty value
 	return "", nil
 }
 
+// updateMergeRequest updates the merge request, and changes the labels, assignees and reviewers from the current ones.
+func updateMergeRequest(client api.MergeRequest, resolver *internal.Resolver, opt *CreateUpdateOption, updateOpt *gitlab.UpdateMergeRequestOptions, mergeRequest *gitlab.MergeRequest, project string) error {
+	if len(opt.Labels) > 0 {
+		labels := gitlab.Labels(internal.ParseLabelChange(opt.Labels).Apply(mergeRequest.Labels))
+		updateOpt.Labels = &labels
+	}
+
+	assigneeIDs, err := opt.getAssigneeIDs(resolver, mergeRequest.Assignees)
+	if err != nil {
+		return err
+	}
+	if assigneeIDs != nil {
+		updateOpt.AssigneeID = nil
+		updateOpt.AssigneeIDs = internal.UpdateAssigneeIDs(assigneeIDs)
+	}
+
+	var reviewerIDs []int
+	if len(opt.Reviewers) > 0 {
+		_, reviewers, err := client.GetMergeRequestWithReviewers(mergeRequest.IID, project)
+		if err != nil {
+			return err
+		}
+		reviewerIDs, err = opt.getReviewerIDs(resolver, reviewers)
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := client.UpdateMergeRequest(updateOpt, mergeRequest.IID, project); err != nil {
+		return err
+	}
+	if reviewerIDs != nil {
+		return client.UpdateMergeRequestReviewers(reviewerIDs, mergeRequest.IID, project)
+	}
+	return nil
+}
+
 func makeUpdateMergeRequestOption(opt *CreateUpdateOption, title, description string) *gitlab.UpdateMergeRequestOptions {
 	updateMergeRequestOptions := &gitlab.UpdateMergeRequestOptions{
-		Title:        gitlab.String(title),
-		Description:  gitlab.String(description),
-		TargetBranch: gitlab.String(opt.TargetBranch),
+		Title:       gitlab.String(opt.applyDraft(title)),
+		Description: gitlab.String(description),
+	}
+	if opt.TargetBranch != "" {
+		updateMergeRequestOptions.TargetBranch = gitlab.String(opt.TargetBranch)
 	}
 	if opt.StateEvent != "" {
 		updateMergeRequestOptions.StateEvent = gitlab.String(opt.StateEvent)
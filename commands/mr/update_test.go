@@ -0,0 +1,41 @@
+package mr
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lighttiger2505/lab/internal/api"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+func Test_updateMergeRequestLabels(t *testing.T) {
+	mergeRequest := &gitlab.MergeRequest{IID: 12, Labels: gitlab.Labels{"bug", "doing"}}
+	tests := []struct {
+		name   string
+		labels []string
+		want   *gitlab.Labels
+	}{
+		{name: "no labels"},
+		{name: "replace", labels: []string{"feature"}, want: &gitlab.Labels{"feature"}},
+		{name: "add and remove", labels: []string{"+feature", "-doing"}, want: &gitlab.Labels{"bug", "feature"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *gitlab.Labels
+			client := &api.MockLabMergeRequestClient{
+				MockUpdateMergeRequest: func(opt *gitlab.UpdateMergeRequestOptions, pid int, repositoryName string) (*gitlab.MergeRequest, error) {
+					got = opt.Labels
+					return mergeRequest, nil
+				},
+			}
+			opt := &CreateUpdateOption{Labels: tt.labels}
+			err := updateMergeRequest(client, nil, opt, &gitlab.UpdateMergeRequestOptions{}, mergeRequest, "group/project")
+			if err != nil {
+				t.Fatalf("updateMergeRequest() error = %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("updateMergeRequest() labels differ: (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
@@ -70,6 +70,7 @@ type PipelineCommand struct {
 	UI              ui.UI
 	RemoteCollecter gitutil.Collecter
 	MethodFactory   MethodFactory
+	TokenRefresher  api.TokenRefresher
 }
 
 func (c *PipelineCommand) Synopsis() string {
@@ -104,7 +105,7 @@ func (c *PipelineCommand) Run(args []string) int {
 		return ExitCodeError
 	}
 
-	clientFacotry, err := api.NewGitlabClientFactory(pInfo.ApiUrl(), pInfo.Token)
+	clientFacotry, err := api.NewGitlabClientFactory(pInfo.ApiUrl(), pInfo.Token, pInfo.TokenType, c.TokenRefresher)
 	if err != nil {
 		c.UI.Error(err.Error())
 		return ExitCodeError
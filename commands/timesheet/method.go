@@ -0,0 +1,250 @@
+package timesheet
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lighttiger2505/lab/internal/api"
+	"github.com/ryanuber/columnize"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+const perPage = 100
+
+// The lengths of the units in the time tracking of GitLab, a day is a work day
+const (
+	hour  = time.Hour
+	day   = 8 * hour
+	week  = 5 * day
+	month = 4 * week
+)
+
+var (
+	spentNoteRegexp = regexp.MustCompile(`^(added|subtracted) (.+?) of time spent`)
+	durationRegexp  = regexp.MustCompile(`^(\d+)(mo|w|d|h|m|s)$`)
+)
+
+var durationUnits = map[string]time.Duration{
+	"mo": month,
+	"w":  week,
+	"d":  day,
+	"h":  hour,
+	"m":  time.Minute,
+	"s":  time.Second,
+}
+
+type timesheetMethod struct {
+	issueClient api.Issue
+	noteClient  api.Note
+	userClient  api.User
+	user        string
+	since       time.Time
+	project     string
+}
+
+// entry is the time spent on an issue by a user
+type entry struct {
+	issue *gitlab.Issue
+	user  string
+	spent time.Duration
+}
+
+func (m *timesheetMethod) Process() (string, error) {
+	user, err := m.username()
+	if err != nil {
+		return "", err
+	}
+
+	issues, err := m.listIssues()
+	if err != nil {
+		return "", err
+	}
+
+	entries := []*entry{}
+	for _, issue := range issues {
+		notes, err := m.listNotes(issue.IID)
+		if err != nil {
+			return "", err
+		}
+		entries = append(entries, sumSpentTime(issue, notes, user, m.since)...)
+	}
+	return timesheetOutput(entries), nil
+}
+
+// username resolves @me to the current user
+func (m *timesheetMethod) username() (string, error) {
+	if m.user != "@me" {
+		return strings.TrimPrefix(m.user, "@"), nil
+	}
+	user, err := m.userClient.CurrentUser()
+	if err != nil {
+		return "", err
+	}
+	return user.Username, nil
+}
+
+// listIssues lists the issues updated since the date, the time is not tracked on the others.
+func (m *timesheetMethod) listIssues() ([]*gitlab.Issue, error) {
+	since := m.since
+	results := []*gitlab.Issue{}
+	for page := 1; ; page++ {
+		issues, err := m.issueClient.GetProjectIssues(&gitlab.ListProjectIssuesOptions{
+			ListOptions: gitlab.ListOptions{
+				Page:    page,
+				PerPage: perPage,
+			},
+			State:        gitlab.String("all"),
+			UpdatedAfter: &since,
+			OrderBy:      gitlab.String("created_at"),
+			Sort:         gitlab.String("asc"),
+		}, m.project)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, issues...)
+		if len(issues) < perPage {
+			return results, nil
+		}
+	}
+}
+
+func (m *timesheetMethod) listNotes(iid int) ([]*gitlab.Note, error) {
+	results := []*gitlab.Note{}
+	for page := 1; ; page++ {
+		notes, err := m.noteClient.GetIssueNotes(m.project, iid, &gitlab.ListIssueNotesOptions{
+			ListOptions: gitlab.ListOptions{
+				Page:    page,
+				PerPage: perPage,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, notes...)
+		if len(notes) < perPage {
+			return results, nil
+		}
+	}
+}
+
+// sumSpentTime sums the time spent by each user from the system notes that GitLab leaves on tracking the time.
+// The API has no time logs, and the note of resetting does not tell the removed time, so it is not counted.
+func sumSpentTime(issue *gitlab.Issue, notes []*gitlab.Note, user string, since time.Time) []*entry {
+	entries := []*entry{}
+	users := map[string]*entry{}
+	for _, note := range notes {
+		if !note.System || note.CreatedAt == nil || note.CreatedAt.Before(since) {
+			continue
+		}
+		if user != "" && note.Author.Username != user {
+			continue
+		}
+		spent, ok := parseSpentNote(note.Body)
+		if !ok {
+			continue
+		}
+		e, ok := users[note.Author.Username]
+		if !ok {
+			e = &entry{issue: issue, user: note.Author.Username}
+			users[note.Author.Username] = e
+			entries = append(entries, e)
+		}
+		e.spent += spent
+	}
+
+	results := []*entry{}
+	for _, e := range entries {
+		if e.spent != 0 {
+			results = append(results, e)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].user < results[j].user
+	})
+	return results
+}
+
+// parseSpentNote parses the note like "added 1h 30m of time spent", the subtracted time is negative.
+func parseSpentNote(body string) (time.Duration, bool) {
+	match := spentNoteRegexp.FindStringSubmatch(body)
+	if match == nil {
+		return 0, false
+	}
+	spent, err := parseDuration(match[2])
+	if err != nil {
+		return 0, false
+	}
+	if match[1] == "subtracted" {
+		spent = -spent
+	}
+	return spent, true
+}
+
+// parseDuration parses the human readable duration of GitLab like "1w 2d 3h 30m".
+func parseDuration(value string) (time.Duration, error) {
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("Invalid duration, %s", value)
+	}
+	var duration time.Duration
+	for _, field := range fields {
+		match := durationRegexp.FindStringSubmatch(field)
+		if match == nil {
+			return 0, fmt.Errorf("Invalid duration, %s", value)
+		}
+		n, err := strconv.Atoi(match[1])
+		if err != nil {
+			return 0, fmt.Errorf("Invalid duration, %s", value)
+		}
+		duration += time.Duration(n) * durationUnits[match[2]]
+	}
+	return duration, nil
+}
+
+// humanDuration formats the duration in work days and hours, as GitLab shows the time spent.
+func humanDuration(duration time.Duration) string {
+	sign := ""
+	if duration < 0 {
+		sign = "-"
+		duration = -duration
+	}
+	units := []struct {
+		name   string
+		length time.Duration
+	}{
+		{"d", day},
+		{"h", hour},
+		{"m", time.Minute},
+	}
+	parts := []string{}
+	for _, unit := range units {
+		if n := duration / unit.length; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", n, unit.name))
+			duration -= n * unit.length
+		}
+	}
+	if len(parts) == 0 {
+		return "0m"
+	}
+	return sign + strings.Join(parts, " ")
+}
+
+func timesheetOutput(entries []*entry) string {
+	var total time.Duration
+	outputs := []string{}
+	for _, e := range entries {
+		outputs = append(outputs, strings.Join([]string{
+			fmt.Sprintf("#%d", e.issue.IID),
+			e.issue.Title,
+			"@" + e.user,
+			humanDuration(e.spent),
+		}, "|"))
+		total += e.spent
+	}
+	outputs = append(outputs, strings.Join([]string{"Total", "", "", humanDuration(total)}, "|"))
+	return columnize.SimpleFormat(outputs)
+}
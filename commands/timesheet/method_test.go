@@ -0,0 +1,160 @@
+package timesheet
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lighttiger2505/lab/internal/api"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+func Test_parseDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "minutes", value: "30m", want: 30 * time.Minute},
+		{name: "hours and minutes", value: "1h 30m", want: 90 * time.Minute},
+		{name: "work days", value: "1w 2d", want: 56 * time.Hour},
+		{name: "month", value: "1mo", want: 160 * time.Hour},
+		{name: "empty", value: "", wantErr: true},
+		{name: "unknown unit", value: "1y", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDuration(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDuration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseSpentNote(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "added", body: "added 1h 30m of time spent", want: 90 * time.Minute, wantOK: true},
+		{name: "added at date", body: "added 2h of time spent at 2019-03-01", want: 2 * time.Hour, wantOK: true},
+		{name: "subtracted", body: "subtracted 30m of time spent", want: -30 * time.Minute, wantOK: true},
+		{name: "reset", body: "removed time spent"},
+		{name: "estimate", body: "changed time estimate to 3h"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseSpentNote(tt.body)
+			if ok != tt.wantOK {
+				t.Fatalf("parseSpentNote() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if got != tt.want {
+				t.Errorf("parseSpentNote() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func newNote(username, body string, createdAt time.Time) *gitlab.Note {
+	note := &gitlab.Note{Body: body, System: true, CreatedAt: &createdAt}
+	note.Author.Username = username
+	return note
+}
+
+func Test_timesheetMethod_Process(t *testing.T) {
+	since := time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)
+	before := since.Add(-time.Hour)
+	after := since.Add(time.Hour)
+
+	issueClient := &api.MockLabIssueClient{
+		MockGetProjectIssues: func(opt *gitlab.ListProjectIssuesOptions, repositoryName string) ([]*gitlab.Issue, error) {
+			if !opt.UpdatedAfter.Equal(since) {
+				t.Errorf("invalid updated after, got %v", opt.UpdatedAfter)
+			}
+			return []*gitlab.Issue{
+				&gitlab.Issue{IID: 1, Title: "first"},
+				&gitlab.Issue{IID: 2, Title: "second"},
+			}, nil
+		},
+	}
+	noteClient := &api.MockNoteClient{
+		MockGetIssueNotes: func(repositoryName string, iid int, opt *gitlab.ListIssueNotesOptions) ([]*gitlab.Note, error) {
+			if iid == 1 {
+				return []*gitlab.Note{
+					newNote("alice", "added 3h of time spent", before),
+					newNote("alice", "added 1h 30m of time spent", after),
+					newNote("alice", "subtracted 30m of time spent", after),
+					newNote("bob", "added 2h of time spent", after),
+					&gitlab.Note{Body: "added 1h of time spent", CreatedAt: &after},
+				}, nil
+			}
+			return []*gitlab.Note{
+				newNote("alice", "added 1d of time spent", after),
+			}, nil
+		},
+	}
+	userClient := &api.MockUserClient{
+		MockCurrentUser: func() (*gitlab.User, error) {
+			return &gitlab.User{Username: "alice"}, nil
+		},
+	}
+
+	tests := []struct {
+		name string
+		user string
+		want string
+	}{
+		{
+			name: "all users",
+			want: strings.Join([]string{
+				"#1     first   @alice  1h",
+				"#1     first   @bob    2h",
+				"#2     second  @alice  1d",
+				"Total                  1d 3h",
+			}, "\n"),
+		},
+		{
+			name: "current user",
+			user: "@me",
+			want: strings.Join([]string{
+				"#1     first   @alice  1h",
+				"#2     second  @alice  1d",
+				"Total                  1d 1h",
+			}, "\n"),
+		},
+		{
+			name: "username",
+			user: "@bob",
+			want: strings.Join([]string{
+				"#1     first  @bob  2h",
+				"Total               2h",
+			}, "\n"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &timesheetMethod{
+				issueClient: issueClient,
+				noteClient:  noteClient,
+				userClient:  userClient,
+				user:        tt.user,
+				since:       since,
+				project:     "group/project",
+			}
+			got, err := m.Process()
+			if err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Process() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
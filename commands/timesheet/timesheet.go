@@ -0,0 +1,113 @@
+package timesheet
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/lighttiger2505/lab/commands/internal"
+	"github.com/lighttiger2505/lab/internal/api"
+	"github.com/lighttiger2505/lab/internal/gitutil"
+	"github.com/lighttiger2505/lab/internal/ui"
+)
+
+const (
+	ExitCodeOK    int = iota //0
+	ExitCodeError int = iota //1
+)
+
+// dateLayout is the format of the since date
+const dateLayout = "2006-01-02"
+
+type Option struct {
+	ProjectProfileOption *internal.ProjectProfileOption `group:"Project, Profile Options"`
+	TimesheetOption      *TimesheetOption               `group:"Timesheet Options"`
+}
+
+type TimesheetOption struct {
+	Since string `long:"since" value-name:"<YYYY-MM-DD>" description:"Sum the time spent on and after the date"`
+	User  string `long:"user" value-name:"<username>" description:"Sum the time spent by the user, @me is yourself"`
+}
+
+func newOptionParser(opt *Option) *flags.Parser {
+	opt.ProjectProfileOption = &internal.ProjectProfileOption{}
+	opt.TimesheetOption = &TimesheetOption{}
+	parser := flags.NewParser(opt, flags.HelpFlag|flags.PassDoubleDash)
+	parser.Usage = `timesheet - Sum the time spent on issues
+
+Synopsis:
+  lab timesheet --since <YYYY-MM-DD> [--user <username> | --user @me]`
+	return parser
+}
+
+type TimesheetCommand struct {
+	UI              ui.UI
+	RemoteCollecter gitutil.Collecter
+	ClientFactory   api.APIClientFactory
+}
+
+func (c *TimesheetCommand) Synopsis() string {
+	return "Sum the time spent on issues"
+}
+
+func (c *TimesheetCommand) Help() string {
+	buf := &bytes.Buffer{}
+	var opt Option
+	parser := newOptionParser(&opt)
+	parser.WriteHelp(buf)
+	return buf.String()
+}
+
+func (c *TimesheetCommand) Run(args []string) int {
+	var opt Option
+	parser := newOptionParser(&opt)
+	if _, err := parser.ParseArgs(args); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+
+	if opt.TimesheetOption.Since == "" {
+		c.UI.Error("Please specify the date with --since")
+		return ExitCodeError
+	}
+	since, err := time.ParseInLocation(dateLayout, opt.TimesheetOption.Since, time.Local)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Invalid date %s, please input as %s", opt.TimesheetOption.Since, dateLayout))
+		return ExitCodeError
+	}
+
+	pInfo, err := c.RemoteCollecter.CollectTarget(
+		opt.ProjectProfileOption.Project,
+		opt.ProjectProfileOption.Profile,
+	)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+
+	if err := c.ClientFactory.Init(pInfo.ApiUrl(), pInfo.Token, pInfo.TokenType); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+
+	method := &timesheetMethod{
+		issueClient: c.ClientFactory.GetIssueClient(),
+		noteClient:  c.ClientFactory.GetNoteClient(),
+		userClient:  c.ClientFactory.GetUserClient(),
+		user:        opt.TimesheetOption.User,
+		since:       since,
+		project:     pInfo.Project,
+	}
+	res, err := method.Process()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+
+	if res != "" {
+		c.UI.Message(res)
+	}
+
+	return ExitCodeOK
+}
@@ -0,0 +1,23 @@
+package todo
+
+import (
+	"github.com/lighttiger2505/lab/internal/api"
+)
+
+type doneMethod struct {
+	client api.Todo
+	opt    *DoneOption
+}
+
+func (m *doneMethod) Process() (string, error) {
+	if m.opt.DoneAll {
+		if err := m.client.MarkAllTodosAsDone(); err != nil {
+			return "", err
+		}
+		return "", nil
+	}
+	if err := m.client.MarkTodoAsDone(m.opt.Done); err != nil {
+		return "", err
+	}
+	return "", nil
+}
@@ -0,0 +1,80 @@
+package todo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lighttiger2505/lab/internal/api"
+	"github.com/ryanuber/columnize"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+type listMethod struct {
+	client api.Todo
+	opt    *ListOption
+}
+
+func (m *listMethod) Process() (string, error) {
+	todos, err := m.client.ListTodos(makeListTodosOptions(m.opt))
+	if err != nil {
+		return "", err
+	}
+	return columnize.SimpleFormat(todoOutput(todos)), nil
+}
+
+// makeListTodosOptions lists the pending todos of the user across the projects.
+func makeListTodosOptions(opt *ListOption) *gitlab.ListTodosOptions {
+	listOpt := &gitlab.ListTodosOptions{
+		ListOptions: gitlab.ListOptions{
+			Page:    1,
+			PerPage: opt.Num,
+		},
+		State: gitlab.String("pending"),
+	}
+	if opt.Action != "" {
+		action := gitlab.TodoAction(opt.Action)
+		listOpt.Action = &action
+	}
+	if opt.Type != "" {
+		listOpt.Type = gitlab.String(targetTypes[opt.Type])
+	}
+	return listOpt
+}
+
+func todoOutput(todos []*gitlab.Todo) []string {
+	outputs := []string{}
+	for _, todo := range todos {
+		output := strings.Join([]string{
+			strconv.Itoa(todo.ID),
+			targetTypeOutput(todo.TargetType),
+			strings.Replace(string(todo.ActionName), "_", " ", -1),
+			todo.Project.PathWithNamespace,
+			targetOutput(todo),
+			"@" + todo.Author.Username,
+		}, "|")
+		outputs = append(outputs, output)
+	}
+	return outputs
+}
+
+func targetTypeOutput(targetType string) string {
+	switch targetType {
+	case "Issue":
+		return "issue"
+	case "MergeRequest":
+		return "merge request"
+	}
+	return strings.ToLower(targetType)
+}
+
+// targetOutput returns the reference and title of the issue or merge request, and the body for the other targets.
+func targetOutput(todo *gitlab.Todo) string {
+	switch todo.TargetType {
+	case "Issue":
+		return fmt.Sprintf("#%d %s", todo.Target.IID, todo.Target.Title)
+	case "MergeRequest":
+		return fmt.Sprintf("!%d %s", todo.Target.IID, todo.Target.Title)
+	}
+	return todo.Body
+}
@@ -0,0 +1,90 @@
+package todo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lighttiger2505/lab/internal/api"
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+func newTodo(id int, targetType string, action gitlab.TodoAction, iid int, title string) *gitlab.Todo {
+	todo := &gitlab.Todo{
+		ID:         id,
+		TargetType: targetType,
+		ActionName: action,
+	}
+	todo.Project.PathWithNamespace = "group/project"
+	todo.Author.Username = "alice"
+	todo.Target.IID = iid
+	todo.Target.Title = title
+	return todo
+}
+
+func Test_listMethod_Process(t *testing.T) {
+	client := &api.MockTodoClient{
+		MockListTodos: func(opt *gitlab.ListTodosOptions) ([]*gitlab.Todo, error) {
+			if *opt.State != "pending" || opt.PerPage != 20 {
+				t.Errorf("invalid list option, state %s, per page %d", *opt.State, opt.PerPage)
+			}
+			if *opt.Type != "MergeRequest" || *opt.Action != "review_requested" {
+				t.Errorf("invalid filter, type %s, action %s", *opt.Type, *opt.Action)
+			}
+			return []*gitlab.Todo{
+				newTodo(1, "Issue", gitlab.TodoMentioned, 12, "Bug"),
+				newTodo(2, "MergeRequest", "review_requested", 3, "Fix bug"),
+			}, nil
+		},
+	}
+	m := &listMethod{
+		client: client,
+		opt:    &ListOption{Num: 20, Action: "review_requested", Type: "mr"},
+	}
+	got, err := m.Process()
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	want := strings.Join([]string{
+		"1  issue          mentioned         group/project  #12 Bug     @alice",
+		"2  merge request  review requested  group/project  !3 Fix bug  @alice",
+	}, "\n")
+	if got != want {
+		t.Errorf("Process() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func Test_doneMethod_Process(t *testing.T) {
+	tests := []struct {
+		name     string
+		opt      *DoneOption
+		wantDone int
+		wantAll  bool
+	}{
+		{name: "done", opt: &DoneOption{Done: 5}, wantDone: 5},
+		{name: "done all", opt: &DoneOption{DoneAll: true}, wantAll: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done, all := 0, false
+			m := &doneMethod{
+				client: &api.MockTodoClient{
+					MockMarkTodoAsDone: func(id int) error {
+						done = id
+						return nil
+					},
+					MockMarkAllTodosAsDone: func() error {
+						all = true
+						return nil
+					},
+				},
+				opt: tt.opt,
+			}
+			if _, err := m.Process(); err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+			if done != tt.wantDone || all != tt.wantAll {
+				t.Errorf("marked %d, all %v, want %d, all %v", done, all, tt.wantDone, tt.wantAll)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package todo
+
+import (
+	"bytes"
+	"fmt"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/lighttiger2505/lab/commands/internal"
+	"github.com/lighttiger2505/lab/internal/api"
+	"github.com/lighttiger2505/lab/internal/gitutil"
+	"github.com/lighttiger2505/lab/internal/ui"
+)
+
+const (
+	ExitCodeOK    int = iota //0
+	ExitCodeError int = iota //1
+)
+
+// targetTypes maps the accepted target types to the target types of the API
+var targetTypes = map[string]string{
+	"issue":         "Issue",
+	"mr":            "MergeRequest",
+	"merge_request": "MergeRequest",
+}
+
+type Option struct {
+	ProjectProfileOption *internal.ProjectProfileOption `group:"Project, Profile Options"`
+	ListOption           *ListOption                    `group:"List Options"`
+	DoneOption           *DoneOption                    `group:"Done Options"`
+}
+
+type ListOption struct {
+	Num    int    `short:"n" long:"num" value-name:"<num>" default:"20" default-mask:"20" description:"Limit the number of todo to output."`
+	Action string `long:"action" value-name:"<action>" description:"Print only todo of the action, e.g. \"assigned\", \"mentioned\" or \"review_requested\""`
+	Type   string `long:"type" value-name:"<type>" description:"Print only todo of the target type. \"issue\" or \"mr\""`
+}
+
+func (l *ListOption) isValid() error {
+	if l.Type != "" {
+		if _, ok := targetTypes[l.Type]; !ok {
+			return fmt.Errorf("Invalid type, %s. Choose from issue, mr", l.Type)
+		}
+	}
+	return nil
+}
+
+type DoneOption struct {
+	Done    int  `long:"done" value-name:"<todo id>" description:"Mark the todo as done"`
+	DoneAll bool `long:"done-all" description:"Mark all the pending todos as done"`
+}
+
+func (d *DoneOption) isValid() error {
+	if d.Done != 0 && d.DoneAll {
+		return fmt.Errorf("Cannot specify both --done and --done-all")
+	}
+	return nil
+}
+
+func newOptionParser(opt *Option) *flags.Parser {
+	opt.ProjectProfileOption = &internal.ProjectProfileOption{}
+	opt.ListOption = &ListOption{}
+	opt.DoneOption = &DoneOption{}
+	parser := flags.NewParser(opt, flags.HelpFlag|flags.PassDoubleDash)
+	parser.Usage = `todo - List the pending todos, and mark them as done
+
+Synopsis:
+  # List todo
+  lab todo [-n <num>] [--action=<action>] [--type=<type>]
+
+  # Mark todo as done
+  lab todo --done=<todo id> | --done-all`
+	return parser
+}
+
+type TodoCommand struct {
+	UI              ui.UI
+	RemoteCollecter gitutil.Collecter
+	ClientFactory   api.APIClientFactory
+}
+
+func (c *TodoCommand) Synopsis() string {
+	return "List the pending todos, and mark them as done"
+}
+
+func (c *TodoCommand) Help() string {
+	buf := &bytes.Buffer{}
+	var opt Option
+	parser := newOptionParser(&opt)
+	parser.WriteHelp(buf)
+	return buf.String()
+}
+
+func (c *TodoCommand) Run(args []string) int {
+	var opt Option
+	parser := newOptionParser(&opt)
+	if _, err := parser.ParseArgs(args); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+
+	if err := opt.ListOption.isValid(); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+	if err := opt.DoneOption.isValid(); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+
+	pInfo, err := c.RemoteCollecter.CollectTarget(
+		opt.ProjectProfileOption.Project,
+		opt.ProjectProfileOption.Profile,
+	)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+
+	if err := c.ClientFactory.Init(pInfo.ApiUrl(), pInfo.Token, pInfo.TokenType); err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+
+	method := c.createMethod(opt)
+	res, err := method.Process()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return ExitCodeError
+	}
+
+	if res != "" {
+		c.UI.Message(res)
+	}
+
+	return ExitCodeOK
+}
+
+func (c *TodoCommand) createMethod(opt Option) internal.Method {
+	client := c.ClientFactory.GetTodoClient()
+
+	if opt.DoneOption.Done != 0 || opt.DoneOption.DoneAll {
+		return &doneMethod{
+			client: client,
+			opt:    opt.DoneOption,
+		}
+	}
+
+	return &listMethod{
+		client: client,
+		opt:    opt.ListOption,
+	}
+}
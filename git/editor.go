@@ -145,13 +145,40 @@ func parceTitleAndBody(text string) (title, body string) {
 	return
 }
 
+// scissors is the line of "git commit --cleanup=scissors".
+const scissors = "------------------------ >8 ------------------------"
+
+// Scissors returns the text ignored by the editor, commented out by the comment char.
+func Scissors(cs string, lines []string) string {
+	commented := []string{
+		cs + " " + scissors,
+		cs + " Do not modify or remove the line above.",
+		cs + " Everything below it will be ignored.",
+	}
+	for _, line := range lines {
+		commented = append(commented, strings.TrimRight(cs+" "+line, " "))
+	}
+	return strings.Join(commented, "\n") + "\n"
+}
+
+// cutScissors removes the scissors line and everything below it.
+func cutScissors(text, cs string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line == cs+" "+scissors {
+			return strings.Join(lines[:i], "\n")
+		}
+	}
+	return text
+}
+
 func readTitleAndBody(reader io.Reader, cs string) (title, body string, err error) {
 	// Reading message file
 	b, err := ioutil.ReadAll(reader)
 	if err != nil {
 		return
 	}
-	text := string(b)
+	text := cutScissors(string(b), cs)
 
 	// Parce title and body from message file
 	title, body = parceTitleAndBody(text)
@@ -77,3 +77,17 @@ A body continues
 		t.Errorf("bad return value want %#v got %#v", want, body)
 	}
 }
+
+func TestReadTitleAndBodyWithScissors(t *testing.T) {
+	message := "A title\n\n# A heading\nA body\n" + Scissors("#", []string{"abc1234 commit", ""}) + "after scissors\n"
+	title, body, err := readTitleAndBody(strings.NewReader(message), "#")
+	if err != nil {
+		t.Fatalf("except %#v", err)
+	}
+	if want := "A title"; want != title {
+		t.Errorf("bad return value want %#v got %#v", want, title)
+	}
+	if want := "# A heading\nA body"; want != body {
+		t.Errorf("bad return value want %#v got %#v", want, body)
+	}
+}
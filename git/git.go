@@ -6,12 +6,24 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 )
 
 type Client interface {
 	RemoteInfos() ([]*RemoteInfo, error)
 	CurrentRemoteBranch() (string, error)
+	UpstreamRemote() (string, error)
+	CommitsAhead(base, branch string) (int, error)
+	Push(remote, branch string) error
+	CommitLog(base, branch string) ([]*Commit, error)
+}
+
+// Commit is a commit listed by "git log".
+type Commit struct {
+	Hash    string
+	Subject string
+	Body    string
 }
 
 type GitClient struct {
@@ -68,6 +80,73 @@ func (g *GitClient) CurrentRemoteBranch() (string, error) {
 
 }
 
+// UpstreamRemote returns the remote that the current branch tracks.
+// It returns an empty string when the branch has no upstream.
+func (g *GitClient) UpstreamRemote() (string, error) {
+	currentBranch, err := CurrentBranch()
+	if err != nil {
+		return "", err
+	}
+	remote, err := Config(fmt.Sprintf("branch.%s.remote", currentBranch))
+	if err != nil {
+		// "git config" fails when the key is not set
+		return "", nil
+	}
+	// "." is the local repository, e.g. a branch tracking another local branch
+	if remote == "." {
+		return "", nil
+	}
+	return remote, nil
+}
+
+// CommitsAhead returns the number of commits in the branch that are not in the base.
+func (g *GitClient) CommitsAhead(base, branch string) (int, error) {
+	outputs, err := gitOutput("rev-list", "--count", fmt.Sprintf("%s..%s", base, branch))
+	if err != nil {
+		return 0, fmt.Errorf("Failed count commits. %s", err)
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(outputs[0]))
+	if err != nil {
+		return 0, fmt.Errorf("Failed count commits. %s", err)
+	}
+	return count, nil
+}
+
+// Push pushes the branch to the remote, and sets it as the upstream of the branch.
+func (g *GitClient) Push(remote, branch string) error {
+	if _, err := gitOutput("push", "--set-upstream", remote, branch); err != nil {
+		return fmt.Errorf("Failed push branch. %s", err)
+	}
+	return nil
+}
+
+// CommitLog returns the commits in the branch that are not in the base, newest first.
+func (g *GitClient) CommitLog(base, branch string) ([]*Commit, error) {
+	// Separate fields by the unit separator and commits by the record separator,
+	// because a body has any number of lines
+	output, err := execCommand("git", "log", "--format=%h%x1f%s%x1f%b%x1e", fmt.Sprintf("%s..%s", base, branch)).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("Failed get commit log. %s\n%s", output, err)
+	}
+	return parseCommitLog(string(output)), nil
+}
+
+func parseCommitLog(output string) []*Commit {
+	commits := []*Commit{}
+	for _, record := range strings.Split(output, "\x1e") {
+		fields := strings.SplitN(strings.TrimSpace(record), "\x1f", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		commits = append(commits, &Commit{
+			Hash:    fields[0],
+			Subject: fields[1],
+			Body:    strings.TrimSpace(fields[2]),
+		})
+	}
+	return commits
+}
+
 func IsGitDirReverseTop() (bool, error) {
 	pos, err := os.Getwd()
 	if err != nil {
@@ -234,6 +313,10 @@ func gitConfigCommand(args []string) []string {
 type MockClient struct {
 	MockRemoteInfos         func() ([]*RemoteInfo, error)
 	MockCurrentRemoteBranch func() (string, error)
+	MockUpstreamRemote      func() (string, error)
+	MockCommitsAhead        func(base, branch string) (int, error)
+	MockPush                func(remote, branch string) error
+	MockCommitLog           func(base, branch string) ([]*Commit, error)
 }
 
 func (m *MockClient) RemoteInfos() ([]*RemoteInfo, error) {
@@ -243,3 +326,31 @@ func (m *MockClient) RemoteInfos() ([]*RemoteInfo, error) {
 func (m *MockClient) CurrentRemoteBranch() (string, error) {
 	return m.MockCurrentRemoteBranch()
 }
+
+func (m *MockClient) UpstreamRemote() (string, error) {
+	if m.MockUpstreamRemote == nil {
+		return "", nil
+	}
+	return m.MockUpstreamRemote()
+}
+
+func (m *MockClient) CommitsAhead(base, branch string) (int, error) {
+	if m.MockCommitsAhead == nil {
+		return 0, nil
+	}
+	return m.MockCommitsAhead(base, branch)
+}
+
+func (m *MockClient) Push(remote, branch string) error {
+	if m.MockPush == nil {
+		return nil
+	}
+	return m.MockPush(remote, branch)
+}
+
+func (m *MockClient) CommitLog(base, branch string) ([]*Commit, error) {
+	if m.MockCommitLog == nil {
+		return nil, nil
+	}
+	return m.MockCommitLog(base, branch)
+}
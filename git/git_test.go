@@ -51,6 +51,19 @@ func TestHelperProcess(*testing.T) {
 				fmt.Fprintf(os.Stderr, "Unknown remote args %v\n", args)
 				os.Exit(2)
 			}
+		case "branch":
+			fmt.Println("  master\n* feature")
+		case "config":
+			switch args[1] {
+			case "branch.feature.remote":
+				fmt.Println("upstream")
+			default:
+				os.Exit(1)
+			}
+		case "rev-list":
+			fmt.Println("3")
+		case "log":
+			fmt.Print("abc1234\x1fAdd feature\x1fThe body\n\nof feature\n\x1e\ndef5678\x1fFix typo\x1f\x1e\n")
 		case "var":
 			fmt.Println("vim")
 		case "rev-parse":
@@ -116,3 +129,49 @@ func TestGitDir(t *testing.T) {
 		t.Errorf("Invalid return value. want %q, got %q", want, got)
 	}
 }
+
+func TestUpstreamRemote(t *testing.T) {
+	execCommand = helperCommand
+	defer func() { execCommand = exec.Command }()
+
+	c := &GitClient{}
+	got, err := c.UpstreamRemote()
+	if err != nil {
+		t.Fatalf("UpstreamRemote() error = %v", err)
+	}
+	if got != "upstream" {
+		t.Errorf("UpstreamRemote() = %q, want %q", got, "upstream")
+	}
+}
+
+func TestCommitsAhead(t *testing.T) {
+	execCommand = helperCommand
+	defer func() { execCommand = exec.Command }()
+
+	c := &GitClient{}
+	got, err := c.CommitsAhead("abcdef", "feature")
+	if err != nil {
+		t.Fatalf("CommitsAhead() error = %v", err)
+	}
+	if got != 3 {
+		t.Errorf("CommitsAhead() = %d, want %d", got, 3)
+	}
+}
+
+func TestCommitLog(t *testing.T) {
+	execCommand = helperCommand
+	defer func() { execCommand = exec.Command }()
+
+	c := &GitClient{}
+	got, err := c.CommitLog("develop", "feature")
+	if err != nil {
+		t.Fatalf("CommitLog() error = %v", err)
+	}
+	want := []*Commit{
+		&Commit{Hash: "abc1234", Subject: "Add feature", Body: "The body\n\nof feature"},
+		&Commit{Hash: "def5678", Subject: "Fix typo", Body: ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CommitLog() = %#v, want %#v", got, want)
+	}
+}
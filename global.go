@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	isatty "github.com/mattn/go-isatty"
+	"github.com/mitchellh/cli"
+)
+
+// globalOption is the options available to every command.
+type globalOption struct {
+	NoInput bool
+	Config  string
+	Remote  string
+}
+
+const globalHelp = `
+
+Global options:
+    --no-input         Never prompt for input nor write the config. Prompts are also disabled when stdin is not a terminal
+    --config <path>    Use the config file instead of the default. Also LAB_CONFIG
+    --remote <name>    Use the git remote to find the project instead of the upstream of the current branch`
+
+// extractGlobalOptions removes the global options from args,
+// so that the command parsers never see them.
+func extractGlobalOptions(args []string) (*globalOption, []string, error) {
+	opt := &globalOption{}
+	values := map[string]*string{
+		"--config": &opt.Config,
+		"--remote": &opt.Remote,
+	}
+
+	rest := []string{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+		if arg == "--no-input" {
+			opt.NoInput = true
+			continue
+		}
+
+		name := strings.SplitN(arg, "=", 2)[0]
+		value, ok := values[name]
+		if !ok {
+			rest = append(rest, arg)
+			continue
+		}
+		if name != arg {
+			*value = strings.TrimPrefix(arg, name+"=")
+			continue
+		}
+		if i+1 >= len(args) {
+			return nil, nil, fmt.Errorf("expected argument for flag `%s'", name)
+		}
+		i++
+		*value = args[i]
+	}
+	return opt, rest, nil
+}
+
+func globalHelpFunc(f func(map[string]cli.CommandFactory) string) func(map[string]cli.CommandFactory) string {
+	return func(commands map[string]cli.CommandFactory) string {
+		return strings.TrimRight(f(commands), "\n") + globalHelp + "\n"
+	}
+}
+
+func isTerminal(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
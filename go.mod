@@ -11,7 +11,7 @@ require (
 	github.com/jessevdk/go-flags v1.4.0
 	github.com/kballard/go-shellquote v0.0.0-20180428030007-95032a82bc51
 	github.com/mattn/go-colorable v0.1.4 // indirect
-	github.com/mattn/go-isatty v0.0.10 // indirect
+	github.com/mattn/go-isatty v0.0.10
 	github.com/mitchellh/cli v1.0.0
 	github.com/posener/complete v1.2.1 // indirect
 	github.com/ryanuber/columnize v0.0.0-20190319233515-9e6335e58db3
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"fmt"
+
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+type Board interface {
+	ListBoards(project string) ([]*gitlab.IssueBoard, error)
+	GetBoard(project string, id int) (*gitlab.IssueBoard, error)
+}
+
+type BoardClient struct {
+	Client *gitlab.Client
+}
+
+func NewBoardClient(client *gitlab.Client) *BoardClient {
+	return &BoardClient{Client: client}
+}
+
+func (c *BoardClient) ListBoards(project string) ([]*gitlab.IssueBoard, error) {
+	boards, _, err := c.Client.Boards.ListIssueBoards(project, &gitlab.ListIssueBoardsOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("Failed list boards, %s", err.Error())
+	}
+	return boards, nil
+}
+
+// GetBoard returns the board with its lists, without the open and closed lists.
+func (c *BoardClient) GetBoard(project string, id int) (*gitlab.IssueBoard, error) {
+	board, _, err := c.Client.Boards.GetIssueBoard(project, id)
+	if err != nil {
+		return nil, fmt.Errorf("Failed get board, %s", err.Error())
+	}
+	return board, nil
+}
+
+type MockBoardClient struct {
+	MockListBoards func(project string) ([]*gitlab.IssueBoard, error)
+	MockGetBoard   func(project string, id int) (*gitlab.IssueBoard, error)
+}
+
+func (m *MockBoardClient) ListBoards(project string) ([]*gitlab.IssueBoard, error) {
+	return m.MockListBoards(project)
+}
+
+func (m *MockBoardClient) GetBoard(project string, id int) (*gitlab.IssueBoard, error) {
+	return m.MockGetBoard(project, id)
+}
@@ -1,11 +1,16 @@
 package api
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 
 	gitlab "github.com/xanzy/go-gitlab"
 )
 
+// ErrBranchNotFound is returned by GetBranch when the branch does not exist in the project.
+var ErrBranchNotFound = errors.New("branch not found")
+
 type Branch interface {
 	GetBranch(project string, branch string) (*gitlab.Branch, error)
 	ListBranches(project string, opt *gitlab.ListBranchesOptions) ([]*gitlab.Branch, error)
@@ -20,7 +25,10 @@ func NewBranchClient(client *gitlab.Client) Branch {
 }
 
 func (c *BranchClient) GetBranch(project string, branch string) (*gitlab.Branch, error) {
-	result, _, err := c.Client.Branches.GetBranch(project, branch)
+	result, res, err := c.Client.Branches.GetBranch(project, branch)
+	if res != nil && res.StatusCode == http.StatusNotFound {
+		return nil, ErrBranchNotFound
+	}
 	if err != nil {
 		return nil, fmt.Errorf("Failed list branches. Error: %s", err.Error())
 	}
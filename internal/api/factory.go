@@ -1,13 +1,31 @@
 package api
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
 
 	gitlab "github.com/xanzy/go-gitlab"
 )
 
+// Token types other than the personal access token
+const (
+	PrivateToken = ""
+	JobToken     = "job"
+	OAuthToken   = "oauth"
+)
+
+// TokenRefresher returns a new OAuth access token in exchange for the expired one.
+// baseURL is the URL of the GitLab that issued the token, e.g. https://gitlab.com
+type TokenRefresher interface {
+	Refresh(baseURL, token string) (string, error)
+}
+
 type APIClientFactory interface {
-	Init(url, token string) error
+	Init(url, token, tokenType string) error
 	GetJobClient() Job
 	GetIssueClient() Issue
 	GetMergeRequestClient() MergeRequest
@@ -21,27 +39,36 @@ type APIClientFactory interface {
 	GetRunnerClient() Runner
 	GetMilestoneClient() Milestone
 	GetBranchClient() Branch
+	GetLabelClient() Label
+	GetBoardClient() Board
+	GetIssueLinkClient() IssueLink
+	GetTodoClient() Todo
+	GetTimeStatsClient() TimeStats
 }
 
 type GitlabClientFactory struct {
+	// Refresher is used when the API returns 401 for an OAuth access token
+	Refresher    TokenRefresher
 	gitlabClient *gitlab.Client
+	// projectClient is shared to cache the projects, e.g. the default branch
+	projectClient *ProjectClient
 }
 
-func NewGitlabClientFactory(url, token string) (APIClientFactory, error) {
-	gitlabClient, err := getGitlabClient(url, token)
-	if err != nil {
+func NewGitlabClientFactory(url, token, tokenType string, refresher TokenRefresher) (APIClientFactory, error) {
+	factory := &GitlabClientFactory{Refresher: refresher}
+	if err := factory.Init(url, token, tokenType); err != nil {
 		return nil, err
 	}
-	factory := &GitlabClientFactory{gitlabClient: gitlabClient}
 	return factory, nil
 }
 
-func (f *GitlabClientFactory) Init(url, token string) error {
-	gitlabClient, err := getGitlabClient(url, token)
+func (f *GitlabClientFactory) Init(url, token, tokenType string) error {
+	gitlabClient, err := getGitlabClient(url, token, tokenType, f.Refresher)
 	if err != nil {
 		return err
 	}
 	f.gitlabClient = gitlabClient
+	f.projectClient = NewProjectClient(gitlabClient)
 	return nil
 }
 
@@ -74,7 +101,7 @@ func (f *GitlabClientFactory) GetPipelineClient() Pipeline {
 }
 
 func (f *GitlabClientFactory) GetProjectClient() Project {
-	return NewProjectClient(f.gitlabClient)
+	return f.projectClient
 }
 
 func (f *GitlabClientFactory) GetUserClient() User {
@@ -97,14 +124,134 @@ func (f *GitlabClientFactory) GetBranchClient() Branch {
 	return NewBranchClient(f.gitlabClient)
 }
 
-func getGitlabClient(url, token string) (*gitlab.Client, error) {
-	client := gitlab.NewClient(nil, token)
+func (f *GitlabClientFactory) GetLabelClient() Label {
+	return NewLabelClient(f.gitlabClient)
+}
+
+func (f *GitlabClientFactory) GetBoardClient() Board {
+	return NewBoardClient(f.gitlabClient)
+}
+
+func (f *GitlabClientFactory) GetIssueLinkClient() IssueLink {
+	return NewIssueLinkClient(f.gitlabClient)
+}
+
+func (f *GitlabClientFactory) GetTodoClient() Todo {
+	return NewTodoClient(f.gitlabClient)
+}
+
+func (f *GitlabClientFactory) GetTimeStatsClient() TimeStats {
+	return NewTimeStatsClient(f.gitlabClient)
+}
+
+func getGitlabClient(url, token, tokenType string, refresher TokenRefresher) (*gitlab.Client, error) {
+	var client *gitlab.Client
+	switch tokenType {
+	case PrivateToken:
+		client = gitlab.NewClient(nil, token)
+	case JobToken:
+		httpClient := &http.Client{
+			Transport: &jobTokenTransport{token: token, base: http.DefaultTransport},
+		}
+		client = gitlab.NewClient(httpClient, "")
+	case OAuthToken:
+		httpClient := &http.Client{
+			Transport: &oauthTransport{
+				token:     token,
+				baseURL:   strings.TrimSuffix(strings.TrimSuffix(url, "/"), "/api/v4"),
+				refresher: refresher,
+				base:      http.DefaultTransport,
+			},
+		}
+		client = gitlab.NewOAuthClient(httpClient, token)
+	default:
+		return nil, fmt.Errorf("Unknown token type, %s", tokenType)
+	}
 	if err := client.SetBaseURL(url); err != nil {
 		return nil, fmt.Errorf("Invalid base url for call GitLab API. %s", err.Error())
 	}
 	return client, nil
 }
 
+// jobTokenTransport sends a CI job token in the JOB-TOKEN header.
+type jobTokenTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (t *jobTokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := new(http.Request)
+	*r = *req
+	r.Header = make(http.Header, len(req.Header))
+	for k, v := range req.Header {
+		r.Header[k] = append([]string(nil), v...)
+	}
+	r.Header.Del("PRIVATE-TOKEN")
+	r.Header.Set("JOB-TOKEN", t.token)
+	return t.base.RoundTrip(r)
+}
+
+// oauthTransport refreshes the OAuth access token and retries once when the API returns 401.
+type oauthTransport struct {
+	mu        sync.Mutex
+	token     string
+	baseURL   string
+	refresher TokenRefresher
+	base      http.RoundTripper
+}
+
+func (t *oauthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		b, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = b
+	}
+
+	t.mu.Lock()
+	token := t.token
+	t.mu.Unlock()
+
+	res, err := t.base.RoundTrip(withBearer(req, token, body))
+	if err != nil || res.StatusCode != http.StatusUnauthorized || t.refresher == nil {
+		return res, err
+	}
+
+	t.mu.Lock()
+	if t.token == token {
+		newToken, rerr := t.refresher.Refresh(t.baseURL, token)
+		if rerr != nil {
+			t.mu.Unlock()
+			res.Body.Close()
+			return nil, fmt.Errorf("token expired and refresh failed: %s", rerr)
+		}
+		t.token = newToken
+	}
+	token = t.token
+	t.mu.Unlock()
+
+	res.Body.Close()
+	return t.base.RoundTrip(withBearer(req, token, body))
+}
+
+func withBearer(req *http.Request, token string, body []byte) *http.Request {
+	r := new(http.Request)
+	*r = *req
+	r.Header = make(http.Header, len(req.Header))
+	for k, v := range req.Header {
+		r.Header[k] = append([]string(nil), v...)
+	}
+	r.Header.Set("Authorization", "Bearer "+token)
+	if body != nil {
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		r.ContentLength = int64(len(body))
+	}
+	return r
+}
+
 type MockAPIClientFactory struct {
 	MockGetJobClient             func() Job
 	MockGetIssueClient           func() Issue
@@ -119,9 +266,14 @@ type MockAPIClientFactory struct {
 	MockGetRunnerClient          func() Runner
 	MockGetMilestoneClient       func() Milestone
 	MockGetBranchClient          func() Branch
+	MockGetLabelClient           func() Label
+	MockGetBoardClient           func() Board
+	MockGetIssueLinkClient       func() IssueLink
+	MockGetTodoClient            func() Todo
+	MockGetTimeStatsClient       func() TimeStats
 }
 
-func (m *MockAPIClientFactory) Init(url, token string) error {
+func (m *MockAPIClientFactory) Init(url, token, tokenType string) error {
 	return nil
 }
 
@@ -176,3 +328,23 @@ func (m *MockAPIClientFactory) GetMilestoneClient() Milestone {
 func (m *MockAPIClientFactory) GetBranchClient() Branch {
 	return m.MockGetBranchClient()
 }
+
+func (m *MockAPIClientFactory) GetLabelClient() Label {
+	return m.MockGetLabelClient()
+}
+
+func (m *MockAPIClientFactory) GetBoardClient() Board {
+	return m.MockGetBoardClient()
+}
+
+func (m *MockAPIClientFactory) GetIssueLinkClient() IssueLink {
+	return m.MockGetIssueLinkClient()
+}
+
+func (m *MockAPIClientFactory) GetTodoClient() Todo {
+	return m.MockGetTodoClient()
+}
+
+func (m *MockAPIClientFactory) GetTimeStatsClient() TimeStats {
+	return m.MockGetTimeStatsClient()
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type mockRefresher struct {
+	count   int
+	baseURL string
+}
+
+func (m *mockRefresher) Refresh(baseURL, token string) (string, error) {
+	m.count++
+	m.baseURL = baseURL
+	if token != "expired" {
+		return "", errors.New("unknown token")
+	}
+	return "refreshed", nil
+}
+
+func TestGitlabClientFactory_OAuthRefresh(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer refreshed" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"message":"401 Unauthorized"}`)
+			return
+		}
+		fmt.Fprint(w, `{"id":1,"username":"lighttiger2505"}`)
+	}))
+	defer server.Close()
+
+	refresher := &mockRefresher{}
+	factory, err := NewGitlabClientFactory(server.URL+"/api/v4", "expired", OAuthToken, refresher)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := factory.GetUserClient()
+	for i := 0; i < 2; i++ {
+		user, err := client.CurrentUser()
+		if err != nil {
+			t.Fatalf("CurrentUser() error = %v", err)
+		}
+		if user.Username != "lighttiger2505" {
+			t.Errorf("CurrentUser() = %v", user.Username)
+		}
+	}
+	if refresher.count != 1 {
+		t.Errorf("refresh count = %d, want 1", refresher.count)
+	}
+	if refresher.baseURL != server.URL {
+		t.Errorf("refresh base url = %s, want %s", refresher.baseURL, server.URL)
+	}
+}
+
+func TestGitlabClientFactory_OAuthRefreshError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"message":"401 Unauthorized"}`)
+	}))
+	defer server.Close()
+
+	factory, err := NewGitlabClientFactory(server.URL+"/api/v4", "revoked", OAuthToken, &mockRefresher{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = factory.GetUserClient().CurrentUser()
+	if err == nil {
+		t.Fatal("CurrentUser() error = nil")
+	}
+	if want := "token expired and refresh failed: unknown token"; !strings.Contains(err.Error(), want) {
+		t.Errorf("CurrentUser() error = %v, want to contain %q", err, want)
+	}
+}
+
+func TestGitlabClientFactory_JobToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("JOB-TOKEN") != "jobtoken" || r.Header.Get("PRIVATE-TOKEN") != "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"message":"401 Unauthorized"}`)
+			return
+		}
+		fmt.Fprint(w, `{"id":1,"username":"lighttiger2505"}`)
+	}))
+	defer server.Close()
+
+	factory, err := NewGitlabClientFactory(server.URL+"/api/v4", "jobtoken", JobToken, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := factory.GetUserClient().CurrentUser(); err != nil {
+		t.Errorf("CurrentUser() error = %v", err)
+	}
+}
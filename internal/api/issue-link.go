@@ -0,0 +1,105 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+// The types of the issue links
+const (
+	LinkTypeRelatesTo   = "relates_to"
+	LinkTypeBlocks      = "blocks"
+	LinkTypeIsBlockedBy = "is_blocked_by"
+)
+
+// LinkedIssue is the issue with the link to the other issue, that go-gitlab does not know.
+type LinkedIssue struct {
+	gitlab.Issue
+	IssueLinkID int    `json:"issue_link_id"`
+	LinkType    string `json:"link_type"`
+}
+
+type IssueLink interface {
+	ListIssueLinks(project string, iid int) ([]*LinkedIssue, error)
+	CreateIssueLink(project string, iid, targetIID int, linkType string) error
+	DeleteIssueLink(project string, iid, linkID int) error
+}
+
+type IssueLinkClient struct {
+	Client *gitlab.Client
+}
+
+func NewIssueLinkClient(client *gitlab.Client) *IssueLinkClient {
+	return &IssueLinkClient{Client: client}
+}
+
+type createIssueLinkOptions struct {
+	TargetProjectID *string `url:"target_project_id,omitempty" json:"target_project_id,omitempty"`
+	TargetIssueIID  *int    `url:"target_issue_iid,omitempty" json:"target_issue_iid,omitempty"`
+	LinkType        *string `url:"link_type,omitempty" json:"link_type,omitempty"`
+}
+
+func issueLinksPath(project string, iid int) string {
+	return fmt.Sprintf("projects/%s/issues/%d/links", url.PathEscape(project), iid)
+}
+
+func (c *IssueLinkClient) ListIssueLinks(project string, iid int) ([]*LinkedIssue, error) {
+	req, err := c.Client.NewRequest("GET", issueLinksPath(project, iid), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Failed list issue links. %s", err.Error())
+	}
+	var issues []*LinkedIssue
+	if _, err := c.Client.Do(req, &issues); err != nil {
+		return nil, fmt.Errorf("Failed list issue links. %s", err.Error())
+	}
+	return issues, nil
+}
+
+// CreateIssueLink links the issue to the other issue in the same project.
+func (c *IssueLinkClient) CreateIssueLink(project string, iid, targetIID int, linkType string) error {
+	opt := &createIssueLinkOptions{
+		TargetProjectID: gitlab.String(project),
+		TargetIssueIID:  gitlab.Int(targetIID),
+		LinkType:        gitlab.String(linkType),
+	}
+	req, err := c.Client.NewRequest("POST", issueLinksPath(project, iid), opt, nil)
+	if err != nil {
+		return fmt.Errorf("Failed create issue link. %s", err.Error())
+	}
+	if _, err := c.Client.Do(req, nil); err != nil {
+		return fmt.Errorf("Failed create issue link. %s", err.Error())
+	}
+	return nil
+}
+
+func (c *IssueLinkClient) DeleteIssueLink(project string, iid, linkID int) error {
+	path := fmt.Sprintf("%s/%d", issueLinksPath(project, iid), linkID)
+	req, err := c.Client.NewRequest("DELETE", path, nil, nil)
+	if err != nil {
+		return fmt.Errorf("Failed delete issue link. %s", err.Error())
+	}
+	if _, err := c.Client.Do(req, nil); err != nil {
+		return fmt.Errorf("Failed delete issue link. %s", err.Error())
+	}
+	return nil
+}
+
+type MockIssueLinkClient struct {
+	MockListIssueLinks  func(project string, iid int) ([]*LinkedIssue, error)
+	MockCreateIssueLink func(project string, iid, targetIID int, linkType string) error
+	MockDeleteIssueLink func(project string, iid, linkID int) error
+}
+
+func (m *MockIssueLinkClient) ListIssueLinks(project string, iid int) ([]*LinkedIssue, error) {
+	return m.MockListIssueLinks(project, iid)
+}
+
+func (m *MockIssueLinkClient) CreateIssueLink(project string, iid, targetIID int, linkType string) error {
+	return m.MockCreateIssueLink(project, iid, targetIID, linkType)
+}
+
+func (m *MockIssueLinkClient) DeleteIssueLink(project string, iid, linkID int) error {
+	return m.MockDeleteIssueLink(project, iid, linkID)
+}
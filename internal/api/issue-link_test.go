@@ -0,0 +1,51 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIssueLinkClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/api/v4/projects/group%2Fproject/issues/1/links" {
+			t.Errorf("invalid path, %s", r.URL.EscapedPath())
+		}
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `[{"iid":2,"title":"second","issue_link_id":5,"link_type":"blocks"}]`)
+		case "POST":
+			body := map[string]interface{}{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if body["target_issue_iid"] != float64(2) || body["link_type"] != "is_blocked_by" || body["target_project_id"] != "group/project" {
+				t.Errorf("invalid body, %v", body)
+			}
+			fmt.Fprint(w, `{}`)
+		default:
+			t.Errorf("invalid method, %s", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	factory, err := NewGitlabClientFactory(server.URL+"/api/v4", "token", PrivateToken, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := factory.GetIssueLinkClient()
+
+	issues, err := client.ListIssueLinks("group/project", 1)
+	if err != nil {
+		t.Fatalf("ListIssueLinks() error = %v", err)
+	}
+	if len(issues) != 1 || issues[0].IID != 2 || issues[0].IssueLinkID != 5 || issues[0].LinkType != LinkTypeBlocks {
+		t.Errorf("ListIssueLinks() = %v", issues)
+	}
+
+	if err := client.CreateIssueLink("group/project", 1, 2, LinkTypeIsBlockedBy); err != nil {
+		t.Fatalf("CreateIssueLink() error = %v", err)
+	}
+}
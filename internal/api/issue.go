@@ -2,6 +2,7 @@ package api
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 
 	gitlab "github.com/xanzy/go-gitlab"
@@ -11,8 +12,14 @@ type Issue interface {
 	GetIssue(pid int, repositoryName string) (*gitlab.Issue, error)
 	GetAllProjectIssues(opt *gitlab.ListIssuesOptions) ([]*gitlab.Issue, error)
 	GetProjectIssues(opt *gitlab.ListProjectIssuesOptions, repositoryName string) ([]*gitlab.Issue, error)
+	GetGroupIssues(opt *gitlab.ListGroupIssuesOptions, group string) ([]*gitlab.Issue, error)
 	CreateIssue(opt *gitlab.CreateIssueOptions, repositoryName string) (*gitlab.Issue, error)
 	UpdateIssue(opt *gitlab.UpdateIssueOptions, pid int, repositoryName string) (*gitlab.Issue, error)
+	GetClosedByMergeRequests(pid int, repositoryName string) ([]*gitlab.MergeRequest, error)
+	GetRelatedMergeRequests(pid int, repositoryName string) ([]*gitlab.MergeRequest, error)
+	SubscribeToIssue(pid int, repositoryName string) error
+	UnsubscribeFromIssue(pid int, repositoryName string) error
+	DeleteIssue(pid int, repositoryName string) error
 }
 
 type IssueClient struct {
@@ -48,6 +55,14 @@ func (c *IssueClient) GetProjectIssues(opt *gitlab.ListProjectIssuesOptions, rep
 	return issues, nil
 }
 
+func (c *IssueClient) GetGroupIssues(opt *gitlab.ListGroupIssuesOptions, group string) ([]*gitlab.Issue, error) {
+	issues, _, err := c.Client.Issues.ListGroupIssues(group, opt)
+	if err != nil {
+		return nil, fmt.Errorf("Failed list group issue. %s", err.Error())
+	}
+	return issues, nil
+}
+
 func (c *IssueClient) CreateIssue(opt *gitlab.CreateIssueOptions, repositoryName string) (*gitlab.Issue, error) {
 	issue, _, err := c.Client.Issues.CreateIssue(
 		repositoryName,
@@ -67,14 +82,64 @@ func (c *IssueClient) UpdateIssue(opt *gitlab.UpdateIssueOptions, pid int, repos
 	return issue, nil
 }
 
+func (c *IssueClient) GetClosedByMergeRequests(pid int, repositoryName string) ([]*gitlab.MergeRequest, error) {
+	mrs, _, err := c.Client.Issues.ListMergeRequestsClosingIssue(repositoryName, pid, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Failed list merge requests closing issue. %s", err.Error())
+	}
+	return mrs, nil
+}
+
+func (c *IssueClient) GetRelatedMergeRequests(pid int, repositoryName string) ([]*gitlab.MergeRequest, error) {
+	mrs, _, err := c.Client.Issues.ListMergeRequestsRelatedToIssue(repositoryName, pid, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Failed list merge requests related to issue. %s", err.Error())
+	}
+	return mrs, nil
+}
+
+func (c *IssueClient) SubscribeToIssue(pid int, repositoryName string) error {
+	_, res, err := c.Client.Issues.SubscribeToIssue(repositoryName, pid)
+	if err != nil && !isNotModified(res) {
+		return fmt.Errorf("Failed subscribe issue. %s", err.Error())
+	}
+	return nil
+}
+
+func (c *IssueClient) UnsubscribeFromIssue(pid int, repositoryName string) error {
+	_, res, err := c.Client.Issues.UnsubscribeFromIssue(repositoryName, pid)
+	if err != nil && !isNotModified(res) {
+		return fmt.Errorf("Failed unsubscribe issue. %s", err.Error())
+	}
+	return nil
+}
+
+func (c *IssueClient) DeleteIssue(pid int, repositoryName string) error {
+	if _, err := c.Client.Issues.DeleteIssue(repositoryName, pid); err != nil {
+		return fmt.Errorf("Failed delete issue. %s", err.Error())
+	}
+	return nil
+}
+
+// isNotModified reports whether GitLab did nothing, as the user has already subscribed or unsubscribed.
+func isNotModified(res *gitlab.Response) bool {
+	return res != nil && res.StatusCode == http.StatusNotModified
+}
+
 type MockLabIssueClient struct {
 	Issue
-	t                       *testing.T
-	MockGetIssue            func(pid int, repositoryName string) (*gitlab.Issue, error)
-	MockGetAllProjectIssues func(opt *gitlab.ListIssuesOptions) ([]*gitlab.Issue, error)
-	MockGetProjectIssues    func(opt *gitlab.ListProjectIssuesOptions, repositoryName string) ([]*gitlab.Issue, error)
-	MockCreateIssue         func(opt *gitlab.CreateIssueOptions, repositoryName string) (*gitlab.Issue, error)
-	MockUpdateIssue         func(opt *gitlab.UpdateIssueOptions, pid int, repositoryName string) (*gitlab.Issue, error)
+	t                            *testing.T
+	MockGetIssue                 func(pid int, repositoryName string) (*gitlab.Issue, error)
+	MockGetAllProjectIssues      func(opt *gitlab.ListIssuesOptions) ([]*gitlab.Issue, error)
+	MockGetProjectIssues         func(opt *gitlab.ListProjectIssuesOptions, repositoryName string) ([]*gitlab.Issue, error)
+	MockGetGroupIssues           func(opt *gitlab.ListGroupIssuesOptions, group string) ([]*gitlab.Issue, error)
+	MockCreateIssue              func(opt *gitlab.CreateIssueOptions, repositoryName string) (*gitlab.Issue, error)
+	MockUpdateIssue              func(opt *gitlab.UpdateIssueOptions, pid int, repositoryName string) (*gitlab.Issue, error)
+	MockGetClosedByMergeRequests func(pid int, repositoryName string) ([]*gitlab.MergeRequest, error)
+	MockGetRelatedMergeRequests  func(pid int, repositoryName string) ([]*gitlab.MergeRequest, error)
+	MockSubscribeToIssue         func(pid int, repositoryName string) error
+	MockUnsubscribeFromIssue     func(pid int, repositoryName string) error
+	MockDeleteIssue              func(pid int, repositoryName string) error
 }
 
 func (m *MockLabIssueClient) GetIssue(pid int, repositoryName string) (*gitlab.Issue, error) {
@@ -89,6 +154,10 @@ func (m *MockLabIssueClient) GetProjectIssues(opt *gitlab.ListProjectIssuesOptio
 	return m.MockGetProjectIssues(opt, repositoryName)
 }
 
+func (m *MockLabIssueClient) GetGroupIssues(opt *gitlab.ListGroupIssuesOptions, group string) ([]*gitlab.Issue, error) {
+	return m.MockGetGroupIssues(opt, group)
+}
+
 func (m *MockLabIssueClient) CreateIssue(opt *gitlab.CreateIssueOptions, repositoryName string) (*gitlab.Issue, error) {
 	return m.MockCreateIssue(opt, repositoryName)
 }
@@ -96,3 +165,23 @@ func (m *MockLabIssueClient) CreateIssue(opt *gitlab.CreateIssueOptions, reposit
 func (m *MockLabIssueClient) UpdateIssue(opt *gitlab.UpdateIssueOptions, pid int, repositoryName string) (*gitlab.Issue, error) {
 	return m.MockUpdateIssue(opt, pid, repositoryName)
 }
+
+func (m *MockLabIssueClient) GetClosedByMergeRequests(pid int, repositoryName string) ([]*gitlab.MergeRequest, error) {
+	return m.MockGetClosedByMergeRequests(pid, repositoryName)
+}
+
+func (m *MockLabIssueClient) GetRelatedMergeRequests(pid int, repositoryName string) ([]*gitlab.MergeRequest, error) {
+	return m.MockGetRelatedMergeRequests(pid, repositoryName)
+}
+
+func (m *MockLabIssueClient) SubscribeToIssue(pid int, repositoryName string) error {
+	return m.MockSubscribeToIssue(pid, repositoryName)
+}
+
+func (m *MockLabIssueClient) UnsubscribeFromIssue(pid int, repositoryName string) error {
+	return m.MockUnsubscribeFromIssue(pid, repositoryName)
+}
+
+func (m *MockLabIssueClient) DeleteIssue(pid int, repositoryName string) error {
+	return m.MockDeleteIssue(pid, repositoryName)
+}
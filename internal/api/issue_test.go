@@ -0,0 +1,42 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIssueClient_SubscribeToIssue(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{name: "subscribed", status: http.StatusCreated},
+		{name: "already subscribed", status: http.StatusNotModified},
+		{name: "not found", status: http.StatusNotFound, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "POST" || r.URL.EscapedPath() != "/api/v4/projects/group%2Fproject/issues/12/subscribe" {
+					t.Errorf("invalid request, %s %s", r.Method, r.URL.EscapedPath())
+				}
+				w.WriteHeader(tt.status)
+				if tt.status == http.StatusCreated {
+					w.Write([]byte(`{"iid":12,"subscribed":true}`))
+				}
+			}))
+			defer server.Close()
+
+			factory, err := NewGitlabClientFactory(server.URL+"/api/v4", "token", PrivateToken, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = factory.GetIssueClient().SubscribeToIssue(12, "group/project")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SubscribeToIssue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
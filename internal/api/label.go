@@ -0,0 +1,58 @@
+package api
+
+import (
+	"fmt"
+
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+type Label interface {
+	ListLabels(project string, opt *gitlab.ListLabelsOptions) ([]*gitlab.Label, error)
+	ListAllLabels(project string) ([]*gitlab.Label, error)
+}
+
+type LabelClient struct {
+	Client *gitlab.Client
+}
+
+func NewLabelClient(client *gitlab.Client) *LabelClient {
+	return &LabelClient{Client: client}
+}
+
+func (c *LabelClient) ListLabels(project string, opt *gitlab.ListLabelsOptions) ([]*gitlab.Label, error) {
+	labels, _, err := c.Client.Labels.ListLabels(project, opt)
+	if err != nil {
+		return nil, fmt.Errorf("Failed list labels, %s", err.Error())
+	}
+	return labels, nil
+}
+
+// ListAllLabels returns all labels of the project, reading every page.
+func (c *LabelClient) ListAllLabels(project string) ([]*gitlab.Label, error) {
+	opt := &gitlab.ListLabelsOptions{Page: 1, PerPage: 100}
+	labels := []*gitlab.Label{}
+	for {
+		page, res, err := c.Client.Labels.ListLabels(project, opt)
+		if err != nil {
+			return nil, fmt.Errorf("Failed list labels, %s", err.Error())
+		}
+		labels = append(labels, page...)
+		if res.NextPage == 0 {
+			return labels, nil
+		}
+		opt.Page = res.NextPage
+	}
+}
+
+type MockLabelClient struct {
+	MockListLabels    func(project string, opt *gitlab.ListLabelsOptions) ([]*gitlab.Label, error)
+	MockListAllLabels func(project string) ([]*gitlab.Label, error)
+}
+
+func (m *MockLabelClient) ListLabels(project string, opt *gitlab.ListLabelsOptions) ([]*gitlab.Label, error) {
+	return m.MockListLabels(project, opt)
+}
+
+func (m *MockLabelClient) ListAllLabels(project string) ([]*gitlab.Label, error) {
+	return m.MockListAllLabels(project)
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"fmt"
+	"net/url"
 
 	gitlab "github.com/xanzy/go-gitlab"
 )
@@ -10,8 +11,14 @@ type MergeRequest interface {
 	GetMergeRequest(pid int, repositoryName string) (*gitlab.MergeRequest, error)
 	GetAllProjectMergeRequest(opt *gitlab.ListMergeRequestsOptions) ([]*gitlab.MergeRequest, error)
 	GetProjectMargeRequest(opt *gitlab.ListProjectMergeRequestsOptions, repositoryName string) ([]*gitlab.MergeRequest, error)
-	CreateMergeRequest(opt *gitlab.CreateMergeRequestOptions, repositoryName string) (*gitlab.MergeRequest, error)
+	GetGroupMergeRequests(opt *ListGroupMergeRequestsOptions, group string) ([]*gitlab.MergeRequest, error)
+	CreateMergeRequest(opt *CreateMergeRequestOptions, repositoryName string) (*gitlab.MergeRequest, error)
 	UpdateMergeRequest(opt *gitlab.UpdateMergeRequestOptions, pid int, repositoryName string) (*gitlab.MergeRequest, error)
+	GetMergeRequestWithReviewers(pid int, repositoryName string) (*gitlab.MergeRequest, []*gitlab.BasicUser, error)
+	UpdateMergeRequestReviewers(reviewerIDs []int, pid int, repositoryName string) error
+	SubscribeToMergeRequest(pid int, repositoryName string) error
+	UnsubscribeFromMergeRequest(pid int, repositoryName string) error
+	DeleteMergeRequest(pid int, repositoryName string) error
 }
 
 type MergeRequestClient struct {
@@ -49,13 +56,38 @@ func (l *MergeRequestClient) GetProjectMargeRequest(opt *gitlab.ListProjectMerge
 	return mergeRequests, nil
 }
 
-func (l *MergeRequestClient) CreateMergeRequest(opt *gitlab.CreateMergeRequestOptions, repositoryName string) (*gitlab.MergeRequest, error) {
-	mergeRequest, _, err := l.Client.MergeRequests.CreateMergeRequest(
-		repositoryName,
-		opt,
-	)
+// ListGroupMergeRequestsOptions adds the draft filter that go-gitlab does not know to the group listing.
+type ListGroupMergeRequestsOptions struct {
+	gitlab.ListGroupMergeRequestsOptions
+	WIP *string `url:"wip,omitempty" json:"wip,omitempty"`
+}
+
+func (l *MergeRequestClient) GetGroupMergeRequests(opt *ListGroupMergeRequestsOptions, group string) ([]*gitlab.MergeRequest, error) {
+	req, err := l.Client.NewRequest("GET", fmt.Sprintf("groups/%s/merge_requests", url.PathEscape(group)), opt, nil)
 	if err != nil {
-		return nil, fmt.Errorf("Failed list project merge requests. %s", err.Error())
+		return nil, fmt.Errorf("Failed list group merge requests. %s", err.Error())
+	}
+	var mergeRequests []*gitlab.MergeRequest
+	if _, err := l.Client.Do(req, &mergeRequests); err != nil {
+		return nil, fmt.Errorf("Failed list group merge requests. %s", err.Error())
+	}
+	return mergeRequests, nil
+}
+
+// CreateMergeRequestOptions adds the reviewers that go-gitlab does not know to the creation.
+type CreateMergeRequestOptions struct {
+	gitlab.CreateMergeRequestOptions
+	ReviewerIDs []int `json:"reviewer_ids,omitempty"`
+}
+
+func (l *MergeRequestClient) CreateMergeRequest(opt *CreateMergeRequestOptions, repositoryName string) (*gitlab.MergeRequest, error) {
+	req, err := l.Client.NewRequest("POST", fmt.Sprintf("projects/%s/merge_requests", url.PathEscape(repositoryName)), opt, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Failed create merge request. %s", err.Error())
+	}
+	mergeRequest := &gitlab.MergeRequest{}
+	if _, err := l.Client.Do(req, mergeRequest); err != nil {
+		return nil, fmt.Errorf("Failed create merge request. %s", err.Error())
 	}
 	return mergeRequest, nil
 }
@@ -68,13 +100,85 @@ func (l *MergeRequestClient) UpdateMergeRequest(opt *gitlab.UpdateMergeRequestOp
 	return mergeRequest, nil
 }
 
+// mergeRequestReviewers is the part of the merge request that go-gitlab does not know.
+type mergeRequestReviewers struct {
+	ReviewerIDs []int `json:"reviewer_ids"`
+}
+
+type mergeRequestWithReviewers struct {
+	gitlab.MergeRequest
+	Reviewers []*gitlab.BasicUser `json:"reviewers"`
+}
+
+func mergeRequestPath(pid int, repositoryName string) string {
+	return fmt.Sprintf("projects/%s/merge_requests/%d", url.PathEscape(repositoryName), pid)
+}
+
+// GetMergeRequestWithReviewers returns the merge request and its reviewers, available since GitLab 13.7.
+func (l *MergeRequestClient) GetMergeRequestWithReviewers(pid int, repositoryName string) (*gitlab.MergeRequest, []*gitlab.BasicUser, error) {
+	req, err := l.Client.NewRequest("GET", mergeRequestPath(pid, repositoryName), nil, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed get merge request. %s", err.Error())
+	}
+	mr := &mergeRequestWithReviewers{}
+	if _, err := l.Client.Do(req, mr); err != nil {
+		return nil, nil, fmt.Errorf("Failed get merge request. %s", err.Error())
+	}
+	return &mr.MergeRequest, mr.Reviewers, nil
+}
+
+// UpdateMergeRequestReviewers replaces the reviewers. An empty list removes all reviewers.
+func (l *MergeRequestClient) UpdateMergeRequestReviewers(reviewerIDs []int, pid int, repositoryName string) error {
+	opt := &mergeRequestReviewers{ReviewerIDs: reviewerIDs}
+	if opt.ReviewerIDs == nil {
+		opt.ReviewerIDs = []int{}
+	}
+	req, err := l.Client.NewRequest("PUT", mergeRequestPath(pid, repositoryName), opt, nil)
+	if err != nil {
+		return fmt.Errorf("Failed update merge request reviewers. %s", err.Error())
+	}
+	if _, err := l.Client.Do(req, nil); err != nil {
+		return fmt.Errorf("Failed update merge request reviewers. %s", err.Error())
+	}
+	return nil
+}
+
+func (l *MergeRequestClient) SubscribeToMergeRequest(pid int, repositoryName string) error {
+	_, res, err := l.Client.MergeRequests.SubscribeToMergeRequest(repositoryName, pid)
+	if err != nil && !isNotModified(res) {
+		return fmt.Errorf("Failed subscribe merge request. %s", err.Error())
+	}
+	return nil
+}
+
+func (l *MergeRequestClient) UnsubscribeFromMergeRequest(pid int, repositoryName string) error {
+	_, res, err := l.Client.MergeRequests.UnsubscribeFromMergeRequest(repositoryName, pid)
+	if err != nil && !isNotModified(res) {
+		return fmt.Errorf("Failed unsubscribe merge request. %s", err.Error())
+	}
+	return nil
+}
+
+func (l *MergeRequestClient) DeleteMergeRequest(pid int, repositoryName string) error {
+	if _, err := l.Client.MergeRequests.DeleteMergeRequest(repositoryName, pid); err != nil {
+		return fmt.Errorf("Failed delete merge request. %s", err.Error())
+	}
+	return nil
+}
+
 type MockLabMergeRequestClient struct {
 	MergeRequest
-	MockGetMergeRequest           func(pid int, repositoryName string) (*gitlab.MergeRequest, error)
-	MockGetAllProjectMergeRequest func(opt *gitlab.ListMergeRequestsOptions) ([]*gitlab.MergeRequest, error)
-	MockGetProjectMargeRequest    func(opt *gitlab.ListProjectMergeRequestsOptions, repositoryName string) ([]*gitlab.MergeRequest, error)
-	MockCreateMergeRequest        func(opt *gitlab.CreateMergeRequestOptions, repositoryName string) (*gitlab.MergeRequest, error)
-	MockUpdateMergeRequest        func(opt *gitlab.UpdateMergeRequestOptions, pid int, repositoryName string) (*gitlab.MergeRequest, error)
+	MockGetMergeRequest              func(pid int, repositoryName string) (*gitlab.MergeRequest, error)
+	MockGetAllProjectMergeRequest    func(opt *gitlab.ListMergeRequestsOptions) ([]*gitlab.MergeRequest, error)
+	MockGetProjectMargeRequest       func(opt *gitlab.ListProjectMergeRequestsOptions, repositoryName string) ([]*gitlab.MergeRequest, error)
+	MockGetGroupMergeRequests        func(opt *ListGroupMergeRequestsOptions, group string) ([]*gitlab.MergeRequest, error)
+	MockCreateMergeRequest           func(opt *CreateMergeRequestOptions, repositoryName string) (*gitlab.MergeRequest, error)
+	MockUpdateMergeRequest           func(opt *gitlab.UpdateMergeRequestOptions, pid int, repositoryName string) (*gitlab.MergeRequest, error)
+	MockGetMergeRequestWithReviewers func(pid int, repositoryName string) (*gitlab.MergeRequest, []*gitlab.BasicUser, error)
+	MockUpdateMergeRequestReviewers  func(reviewerIDs []int, pid int, repositoryName string) error
+	MockSubscribeToMergeRequest      func(pid int, repositoryName string) error
+	MockUnsubscribeFromMergeRequest  func(pid int, repositoryName string) error
+	MockDeleteMergeRequest           func(pid int, repositoryName string) error
 }
 
 func (m *MockLabMergeRequestClient) GetMergeRequest(pid int, repositoryName string) (*gitlab.MergeRequest, error) {
@@ -89,10 +193,42 @@ func (m *MockLabMergeRequestClient) GetProjectMargeRequest(opt *gitlab.ListProje
 	return m.MockGetProjectMargeRequest(opt, repositoryName)
 }
 
-func (m *MockLabMergeRequestClient) CreateMergeRequest(opt *gitlab.CreateMergeRequestOptions, repositoryName string) (*gitlab.MergeRequest, error) {
+func (m *MockLabMergeRequestClient) GetGroupMergeRequests(opt *ListGroupMergeRequestsOptions, group string) ([]*gitlab.MergeRequest, error) {
+	return m.MockGetGroupMergeRequests(opt, group)
+}
+
+func (m *MockLabMergeRequestClient) CreateMergeRequest(opt *CreateMergeRequestOptions, repositoryName string) (*gitlab.MergeRequest, error) {
 	return m.MockCreateMergeRequest(opt, repositoryName)
 }
 
 func (m *MockLabMergeRequestClient) UpdateMergeRequest(opt *gitlab.UpdateMergeRequestOptions, pid int, repositoryName string) (*gitlab.MergeRequest, error) {
 	return m.MockUpdateMergeRequest(opt, pid, repositoryName)
 }
+
+// GetMergeRequestWithReviewers returns the merge request of MockGetMergeRequest without reviewers when it is not mocked.
+func (m *MockLabMergeRequestClient) GetMergeRequestWithReviewers(pid int, repositoryName string) (*gitlab.MergeRequest, []*gitlab.BasicUser, error) {
+	if m.MockGetMergeRequestWithReviewers == nil {
+		mergeRequest, err := m.MockGetMergeRequest(pid, repositoryName)
+		return mergeRequest, nil, err
+	}
+	return m.MockGetMergeRequestWithReviewers(pid, repositoryName)
+}
+
+func (m *MockLabMergeRequestClient) UpdateMergeRequestReviewers(reviewerIDs []int, pid int, repositoryName string) error {
+	if m.MockUpdateMergeRequestReviewers == nil {
+		return nil
+	}
+	return m.MockUpdateMergeRequestReviewers(reviewerIDs, pid, repositoryName)
+}
+
+func (m *MockLabMergeRequestClient) SubscribeToMergeRequest(pid int, repositoryName string) error {
+	return m.MockSubscribeToMergeRequest(pid, repositoryName)
+}
+
+func (m *MockLabMergeRequestClient) UnsubscribeFromMergeRequest(pid int, repositoryName string) error {
+	return m.MockUnsubscribeFromMergeRequest(pid, repositoryName)
+}
+
+func (m *MockLabMergeRequestClient) DeleteMergeRequest(pid int, repositoryName string) error {
+	return m.MockDeleteMergeRequest(pid, repositoryName)
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+func TestMergeRequestClient_GetGroupMergeRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/api/v4/groups/group%2Fsub/merge_requests" {
+			t.Errorf("invalid path, %s", r.URL.EscapedPath())
+		}
+		query := r.URL.Query()
+		if query.Get("wip") != "no" || query.Get("state") != "opened" || query.Get("per_page") != "5" {
+			t.Errorf("invalid query, %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `[{"iid":1,"title":"first"},{"iid":2,"title":"second"}]`)
+	}))
+	defer server.Close()
+
+	factory, err := NewGitlabClientFactory(server.URL+"/api/v4", "token", PrivateToken, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opt := &ListGroupMergeRequestsOptions{
+		ListGroupMergeRequestsOptions: gitlab.ListGroupMergeRequestsOptions{
+			ListOptions: gitlab.ListOptions{PerPage: 5},
+			State:       gitlab.String("opened"),
+		},
+		WIP: gitlab.String("no"),
+	}
+	mergeRequests, err := factory.GetMergeRequestClient().GetGroupMergeRequests(opt, "group/sub")
+	if err != nil {
+		t.Fatalf("GetGroupMergeRequests() error = %v", err)
+	}
+	if len(mergeRequests) != 2 || mergeRequests[1].Title != "second" {
+		t.Errorf("GetGroupMergeRequests() = %v", mergeRequests)
+	}
+}
+
+func TestMergeRequestClient_Reviewers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			if r.URL.EscapedPath() != "/api/v4/projects/group%2Fproject/merge_requests" {
+				t.Errorf("invalid path, %s", r.URL.EscapedPath())
+			}
+			body := map[string]interface{}{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if body["title"] != "title" || fmt.Sprint(body["reviewer_ids"]) != "[2 3]" {
+				t.Errorf("invalid body, %v", body)
+			}
+			fmt.Fprint(w, `{"iid":12}`)
+		case "GET":
+			if r.URL.EscapedPath() != "/api/v4/projects/group%2Fproject/merge_requests/12" {
+				t.Errorf("invalid path, %s", r.URL.EscapedPath())
+			}
+			fmt.Fprint(w, `{"iid":12,"title":"title","reviewers":[{"id":2,"username":"bob"}]}`)
+		default:
+			t.Errorf("invalid method, %s", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	factory, err := NewGitlabClientFactory(server.URL+"/api/v4", "token", PrivateToken, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := factory.GetMergeRequestClient()
+
+	opt := &CreateMergeRequestOptions{
+		CreateMergeRequestOptions: gitlab.CreateMergeRequestOptions{Title: gitlab.String("title")},
+		ReviewerIDs:               []int{2, 3},
+	}
+	created, err := client.CreateMergeRequest(opt, "group/project")
+	if err != nil {
+		t.Fatalf("CreateMergeRequest() error = %v", err)
+	}
+	if created.IID != 12 {
+		t.Errorf("CreateMergeRequest() = %v", created)
+	}
+
+	mergeRequest, reviewers, err := client.GetMergeRequestWithReviewers(12, "group/project")
+	if err != nil {
+		t.Fatalf("GetMergeRequestWithReviewers() error = %v", err)
+	}
+	if mergeRequest.Title != "title" || len(reviewers) != 1 || reviewers[0].Username != "bob" {
+		t.Errorf("GetMergeRequestWithReviewers() = %v, %v", mergeRequest, reviewers)
+	}
+}
@@ -2,12 +2,20 @@ package api
 
 import (
 	"fmt"
+	"net/url"
 
 	gitlab "github.com/xanzy/go-gitlab"
 )
 
 type Milestone interface {
 	ListMilestones(project string, opt *gitlab.ListMilestonesOptions) ([]*gitlab.Milestone, error)
+	ListAllMilestones(project string) ([]*gitlab.Milestone, error)
+	GetMilestone(project string, id int) (*gitlab.Milestone, error)
+	CreateMilestone(project string, opt *gitlab.CreateMilestoneOptions) (*gitlab.Milestone, error)
+	UpdateMilestone(project string, id int, opt *gitlab.UpdateMilestoneOptions) (*gitlab.Milestone, error)
+	GetMilestoneIssues(project string, id int) ([]*gitlab.Issue, error)
+	GetMilestoneMergeRequests(project string, id int) ([]*gitlab.MergeRequest, error)
+	ListGroupMilestones(group string, opt *gitlab.ListGroupMilestonesOptions) ([]*gitlab.GroupMilestone, error)
 }
 
 type MilestoneClient struct {
@@ -26,10 +34,143 @@ func (c *MilestoneClient) ListMilestones(project string, opt *gitlab.ListMilesto
 	return milestones, nil
 }
 
+// listAllMilestonesOptions adds the milestones of the ancestor groups that go-gitlab does not know to the listing.
+type listAllMilestonesOptions struct {
+	gitlab.ListMilestonesOptions
+	IncludeParentMilestones *bool `url:"include_parent_milestones,omitempty"`
+}
+
+// ListAllMilestones returns all milestones of the project and its ancestor groups, reading every page.
+// The milestones of the groups have no project ID.
+func (c *MilestoneClient) ListAllMilestones(project string) ([]*gitlab.Milestone, error) {
+	opt := &listAllMilestonesOptions{
+		ListMilestonesOptions:   gitlab.ListMilestonesOptions{ListOptions: gitlab.ListOptions{Page: 1, PerPage: 100}},
+		IncludeParentMilestones: gitlab.Bool(true),
+	}
+	milestones := []*gitlab.Milestone{}
+	for {
+		req, err := c.Client.NewRequest("GET", fmt.Sprintf("projects/%s/milestones", url.PathEscape(project)), opt, nil)
+		if err != nil {
+			return nil, fmt.Errorf("Failed list milestone, %s", err.Error())
+		}
+		var page []*gitlab.Milestone
+		res, err := c.Client.Do(req, &page)
+		if err != nil {
+			return nil, fmt.Errorf("Failed list milestone, %s", err.Error())
+		}
+		milestones = append(milestones, page...)
+		if res.NextPage == 0 {
+			return milestones, nil
+		}
+		opt.Page = res.NextPage
+	}
+}
+
+func (c *MilestoneClient) GetMilestone(project string, id int) (*gitlab.Milestone, error) {
+	milestone, _, err := c.Client.Milestones.GetMilestone(project, id)
+	if err != nil {
+		return nil, fmt.Errorf("Failed get milestone, %s", err.Error())
+	}
+	return milestone, nil
+}
+
+func (c *MilestoneClient) CreateMilestone(project string, opt *gitlab.CreateMilestoneOptions) (*gitlab.Milestone, error) {
+	milestone, _, err := c.Client.Milestones.CreateMilestone(project, opt)
+	if err != nil {
+		return nil, fmt.Errorf("Failed create milestone, %s", err.Error())
+	}
+	return milestone, nil
+}
+
+func (c *MilestoneClient) UpdateMilestone(project string, id int, opt *gitlab.UpdateMilestoneOptions) (*gitlab.Milestone, error) {
+	milestone, _, err := c.Client.Milestones.UpdateMilestone(project, id, opt)
+	if err != nil {
+		return nil, fmt.Errorf("Failed update milestone, %s", err.Error())
+	}
+	return milestone, nil
+}
+
+// GetMilestoneIssues returns all issues of the milestone, reading every page.
+func (c *MilestoneClient) GetMilestoneIssues(project string, id int) ([]*gitlab.Issue, error) {
+	opt := &gitlab.GetMilestoneIssuesOptions{Page: 1, PerPage: 100}
+	issues := []*gitlab.Issue{}
+	for {
+		page, res, err := c.Client.Milestones.GetMilestoneIssues(project, id, opt)
+		if err != nil {
+			return nil, fmt.Errorf("Failed get milestone issues, %s", err.Error())
+		}
+		issues = append(issues, page...)
+		if res.NextPage == 0 {
+			return issues, nil
+		}
+		opt.Page = res.NextPage
+	}
+}
+
+// GetMilestoneMergeRequests returns all merge requests of the milestone, reading every page.
+func (c *MilestoneClient) GetMilestoneMergeRequests(project string, id int) ([]*gitlab.MergeRequest, error) {
+	opt := &gitlab.GetMilestoneMergeRequestsOptions{Page: 1, PerPage: 100}
+	mergeRequests := []*gitlab.MergeRequest{}
+	for {
+		page, res, err := c.Client.Milestones.GetMilestoneMergeRequests(project, id, opt)
+		if err != nil {
+			return nil, fmt.Errorf("Failed get milestone merge requests, %s", err.Error())
+		}
+		mergeRequests = append(mergeRequests, page...)
+		if res.NextPage == 0 {
+			return mergeRequests, nil
+		}
+		opt.Page = res.NextPage
+	}
+}
+
+func (c *MilestoneClient) ListGroupMilestones(group string, opt *gitlab.ListGroupMilestonesOptions) ([]*gitlab.GroupMilestone, error) {
+	milestones, _, err := c.Client.GroupMilestones.ListGroupMilestones(group, opt)
+	if err != nil {
+		return nil, fmt.Errorf("Failed list group milestone, %s", err.Error())
+	}
+	return milestones, nil
+}
+
 type MockMilestoneClient struct {
-	MockListMilestones func(project string, opt *gitlab.ListMilestonesOptions) ([]*gitlab.Milestone, error)
+	MockListMilestones            func(project string, opt *gitlab.ListMilestonesOptions) ([]*gitlab.Milestone, error)
+	MockListAllMilestones         func(project string) ([]*gitlab.Milestone, error)
+	MockGetMilestone              func(project string, id int) (*gitlab.Milestone, error)
+	MockCreateMilestone           func(project string, opt *gitlab.CreateMilestoneOptions) (*gitlab.Milestone, error)
+	MockUpdateMilestone           func(project string, id int, opt *gitlab.UpdateMilestoneOptions) (*gitlab.Milestone, error)
+	MockGetMilestoneIssues        func(project string, id int) ([]*gitlab.Issue, error)
+	MockGetMilestoneMergeRequests func(project string, id int) ([]*gitlab.MergeRequest, error)
+	MockListGroupMilestones       func(group string, opt *gitlab.ListGroupMilestonesOptions) ([]*gitlab.GroupMilestone, error)
 }
 
 func (m *MockMilestoneClient) ListMilestones(project string, opt *gitlab.ListMilestonesOptions) ([]*gitlab.Milestone, error) {
 	return m.MockListMilestones(project, opt)
 }
+
+func (m *MockMilestoneClient) ListAllMilestones(project string) ([]*gitlab.Milestone, error) {
+	return m.MockListAllMilestones(project)
+}
+
+func (m *MockMilestoneClient) GetMilestone(project string, id int) (*gitlab.Milestone, error) {
+	return m.MockGetMilestone(project, id)
+}
+
+func (m *MockMilestoneClient) CreateMilestone(project string, opt *gitlab.CreateMilestoneOptions) (*gitlab.Milestone, error) {
+	return m.MockCreateMilestone(project, opt)
+}
+
+func (m *MockMilestoneClient) UpdateMilestone(project string, id int, opt *gitlab.UpdateMilestoneOptions) (*gitlab.Milestone, error) {
+	return m.MockUpdateMilestone(project, id, opt)
+}
+
+func (m *MockMilestoneClient) GetMilestoneIssues(project string, id int) ([]*gitlab.Issue, error) {
+	return m.MockGetMilestoneIssues(project, id)
+}
+
+func (m *MockMilestoneClient) GetMilestoneMergeRequests(project string, id int) ([]*gitlab.MergeRequest, error) {
+	return m.MockGetMilestoneMergeRequests(project, id)
+}
+
+func (m *MockMilestoneClient) ListGroupMilestones(group string, opt *gitlab.ListGroupMilestonesOptions) ([]*gitlab.GroupMilestone, error) {
+	return m.MockListGroupMilestones(group, opt)
+}
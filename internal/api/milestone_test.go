@@ -0,0 +1,39 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMilestoneClient_ListAllMilestones(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/api/v4/projects/group%2Fproject/milestones" {
+			t.Errorf("invalid path, %s", r.URL.EscapedPath())
+		}
+		query := r.URL.Query()
+		if query.Get("include_parent_milestones") != "true" || query.Get("per_page") != "100" {
+			t.Errorf("invalid query, %s", r.URL.RawQuery)
+		}
+		if query.Get("page") == "1" {
+			w.Header().Set("X-Next-Page", "2")
+			fmt.Fprint(w, `[{"id":10,"iid":1,"project_id":3,"title":"v1.0"}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"id":20,"iid":1,"title":"Group Sprint"}]`)
+	}))
+	defer server.Close()
+
+	factory, err := NewGitlabClientFactory(server.URL+"/api/v4", "token", PrivateToken, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	milestones, err := factory.GetMilestoneClient().ListAllMilestones("group/project")
+	if err != nil {
+		t.Fatalf("ListAllMilestones() error = %v", err)
+	}
+	if len(milestones) != 2 || milestones[1].Title != "Group Sprint" || milestones[1].ProjectID != 0 {
+		t.Errorf("ListAllMilestones() = %v", milestones)
+	}
+}
@@ -2,20 +2,31 @@ package api
 
 import (
 	"fmt"
+	"sync"
 
 	gitlab "github.com/xanzy/go-gitlab"
 )
 
+// DefaultBranchFallback is used when the project has no default branch, e.g. an empty repository.
+const DefaultBranchFallback = "master"
+
 type Project interface {
 	Projects(opt *gitlab.ListProjectsOptions) ([]*gitlab.Project, error)
+	GetProject(project string) (*gitlab.Project, error)
+	DefaultBranch(project string) (string, error)
 }
 
 type ProjectClient struct {
 	Client *gitlab.Client
+	mu     sync.Mutex
+	cache  map[string]*gitlab.Project
 }
 
 func NewProjectClient(client *gitlab.Client) *ProjectClient {
-	return &ProjectClient{Client: client}
+	return &ProjectClient{
+		Client: client,
+		cache:  map[string]*gitlab.Project{},
+	}
 }
 
 func (c *ProjectClient) Projects(opt *gitlab.ListProjectsOptions) ([]*gitlab.Project, error) {
@@ -26,10 +37,47 @@ func (c *ProjectClient) Projects(opt *gitlab.ListProjectsOptions) ([]*gitlab.Pro
 	return projects, nil
 }
 
+// GetProject returns the project. The project is fetched once and cached by the client.
+func (c *ProjectClient) GetProject(project string) (*gitlab.Project, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if p, ok := c.cache[project]; ok {
+		return p, nil
+	}
+
+	p, _, err := c.Client.Projects.GetProject(project, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Failed get project. Error: %s", err.Error())
+	}
+	c.cache[project] = p
+	return p, nil
+}
+
+func (c *ProjectClient) DefaultBranch(project string) (string, error) {
+	p, err := c.GetProject(project)
+	if err != nil {
+		return "", err
+	}
+	if p.DefaultBranch == "" {
+		return DefaultBranchFallback, nil
+	}
+	return p.DefaultBranch, nil
+}
+
 type MockProjectClient struct {
-	MockProjects func(opt *gitlab.ListProjectsOptions) ([]*gitlab.Project, error)
+	MockProjects      func(opt *gitlab.ListProjectsOptions) ([]*gitlab.Project, error)
+	MockGetProject    func(project string) (*gitlab.Project, error)
+	MockDefaultBranch func(project string) (string, error)
 }
 
 func (m *MockProjectClient) Projects(opt *gitlab.ListProjectsOptions) ([]*gitlab.Project, error) {
 	return m.MockProjects(opt)
 }
+
+func (m *MockProjectClient) GetProject(project string) (*gitlab.Project, error) {
+	return m.MockGetProject(project)
+}
+
+func (m *MockProjectClient) DefaultBranch(project string) (string, error) {
+	return m.MockDefaultBranch(project)
+}
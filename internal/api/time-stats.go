@@ -0,0 +1,99 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+// The entities that track the time
+const (
+	IssueEntity        = "issues"
+	MergeRequestEntity = "merge_requests"
+)
+
+type TimeStats interface {
+	SetTimeEstimate(project, entity string, iid int, duration string) (*gitlab.TimeStats, error)
+	AddSpentTime(project, entity string, iid int, duration, summary string) (*gitlab.TimeStats, error)
+	ResetSpentTime(project, entity string, iid int) (*gitlab.TimeStats, error)
+}
+
+type TimeStatsClient struct {
+	Client *gitlab.Client
+}
+
+func NewTimeStatsClient(client *gitlab.Client) *TimeStatsClient {
+	return &TimeStatsClient{Client: client}
+}
+
+// addSpentTimeOptions adds the summary that go-gitlab does not know, available since GitLab 13.x.
+type addSpentTimeOptions struct {
+	Duration *string `url:"duration,omitempty" json:"duration,omitempty"`
+	Summary  *string `url:"summary,omitempty" json:"summary,omitempty"`
+}
+
+func timeStatsPath(project, entity string, iid int, action string) string {
+	return fmt.Sprintf("projects/%s/%s/%d/%s", url.PathEscape(project), entity, iid, action)
+}
+
+func (c *TimeStatsClient) post(path string, opt interface{}) (*gitlab.TimeStats, error) {
+	req, err := c.Client.NewRequest("POST", path, opt, nil)
+	if err != nil {
+		return nil, err
+	}
+	stats := &gitlab.TimeStats{}
+	if _, err := c.Client.Do(req, stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (c *TimeStatsClient) SetTimeEstimate(project, entity string, iid int, duration string) (*gitlab.TimeStats, error) {
+	stats, err := c.post(
+		timeStatsPath(project, entity, iid, "time_estimate"),
+		&gitlab.SetTimeEstimateOptions{Duration: gitlab.String(duration)},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Failed set time estimate. %s", err.Error())
+	}
+	return stats, nil
+}
+
+func (c *TimeStatsClient) AddSpentTime(project, entity string, iid int, duration, summary string) (*gitlab.TimeStats, error) {
+	opt := &addSpentTimeOptions{Duration: gitlab.String(duration)}
+	if summary != "" {
+		opt.Summary = gitlab.String(summary)
+	}
+	stats, err := c.post(timeStatsPath(project, entity, iid, "add_spent_time"), opt)
+	if err != nil {
+		return nil, fmt.Errorf("Failed add spent time. %s", err.Error())
+	}
+	return stats, nil
+}
+
+func (c *TimeStatsClient) ResetSpentTime(project, entity string, iid int) (*gitlab.TimeStats, error) {
+	stats, err := c.post(timeStatsPath(project, entity, iid, "reset_spent_time"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("Failed reset spent time. %s", err.Error())
+	}
+	return stats, nil
+}
+
+type MockTimeStatsClient struct {
+	MockSetTimeEstimate func(project, entity string, iid int, duration string) (*gitlab.TimeStats, error)
+	MockAddSpentTime    func(project, entity string, iid int, duration, summary string) (*gitlab.TimeStats, error)
+	MockResetSpentTime  func(project, entity string, iid int) (*gitlab.TimeStats, error)
+}
+
+func (m *MockTimeStatsClient) SetTimeEstimate(project, entity string, iid int, duration string) (*gitlab.TimeStats, error) {
+	return m.MockSetTimeEstimate(project, entity, iid, duration)
+}
+
+func (m *MockTimeStatsClient) AddSpentTime(project, entity string, iid int, duration, summary string) (*gitlab.TimeStats, error) {
+	return m.MockAddSpentTime(project, entity, iid, duration, summary)
+}
+
+func (m *MockTimeStatsClient) ResetSpentTime(project, entity string, iid int) (*gitlab.TimeStats, error) {
+	return m.MockResetSpentTime(project, entity, iid)
+}
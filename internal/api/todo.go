@@ -0,0 +1,61 @@
+package api
+
+import (
+	"fmt"
+
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+type Todo interface {
+	ListTodos(opt *gitlab.ListTodosOptions) ([]*gitlab.Todo, error)
+	MarkTodoAsDone(id int) error
+	MarkAllTodosAsDone() error
+}
+
+type TodoClient struct {
+	Client *gitlab.Client
+}
+
+func NewTodoClient(client *gitlab.Client) *TodoClient {
+	return &TodoClient{Client: client}
+}
+
+func (c *TodoClient) ListTodos(opt *gitlab.ListTodosOptions) ([]*gitlab.Todo, error) {
+	todos, _, err := c.Client.Todos.ListTodos(opt)
+	if err != nil {
+		return nil, fmt.Errorf("Failed list todos. %s", err.Error())
+	}
+	return todos, nil
+}
+
+func (c *TodoClient) MarkTodoAsDone(id int) error {
+	if _, err := c.Client.Todos.MarkTodoAsDone(id); err != nil {
+		return fmt.Errorf("Failed mark todo as done. %s", err.Error())
+	}
+	return nil
+}
+
+func (c *TodoClient) MarkAllTodosAsDone() error {
+	if _, err := c.Client.Todos.MarkAllTodosAsDone(); err != nil {
+		return fmt.Errorf("Failed mark all todos as done. %s", err.Error())
+	}
+	return nil
+}
+
+type MockTodoClient struct {
+	MockListTodos          func(opt *gitlab.ListTodosOptions) ([]*gitlab.Todo, error)
+	MockMarkTodoAsDone     func(id int) error
+	MockMarkAllTodosAsDone func() error
+}
+
+func (m *MockTodoClient) ListTodos(opt *gitlab.ListTodosOptions) ([]*gitlab.Todo, error) {
+	return m.MockListTodos(opt)
+}
+
+func (m *MockTodoClient) MarkTodoAsDone(id int) error {
+	return m.MockMarkTodoAsDone(id)
+}
+
+func (m *MockTodoClient) MarkAllTodosAsDone() error {
+	return m.MockMarkAllTodosAsDone()
+}
@@ -9,6 +9,8 @@ import (
 type User interface {
 	Users(opt *gitlab.ListUsersOptions) ([]*gitlab.User, error)
 	ProjectUsers(repositoryName string, opt *gitlab.ListProjectUserOptions) ([]*gitlab.ProjectUser, error)
+	CurrentUser() (*gitlab.User, error)
+	CurrentTokenScopes() ([]string, error)
 }
 
 type UserClient struct {
@@ -35,9 +37,37 @@ func (c *UserClient) ProjectUsers(repositoryName string, opt *gitlab.ListProject
 	return results, nil
 }
 
+func (c *UserClient) CurrentUser() (*gitlab.User, error) {
+	result, _, err := c.Client.Users.CurrentUser()
+	if err != nil {
+		return nil, fmt.Errorf("Failed get current user. Error: %s", err.Error())
+	}
+	return result, nil
+}
+
+type personalAccessToken struct {
+	Scopes []string `json:"scopes"`
+}
+
+// CurrentTokenScopes returns the scopes of the personal access token in use.
+// The endpoint is available since GitLab 15.5.
+func (c *UserClient) CurrentTokenScopes() ([]string, error) {
+	req, err := c.Client.NewRequest("GET", "personal_access_tokens/self", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Failed get token scopes. Error: %s", err.Error())
+	}
+	token := &personalAccessToken{}
+	if _, err := c.Client.Do(req, token); err != nil {
+		return nil, fmt.Errorf("Failed get token scopes. Error: %s", err.Error())
+	}
+	return token.Scopes, nil
+}
+
 type MockUserClient struct {
-	MockUsers        func(opt *gitlab.ListUsersOptions) ([]*gitlab.User, error)
-	MockProjectUsers func(repositoryName string, opt *gitlab.ListProjectUserOptions) ([]*gitlab.ProjectUser, error)
+	MockUsers              func(opt *gitlab.ListUsersOptions) ([]*gitlab.User, error)
+	MockProjectUsers       func(repositoryName string, opt *gitlab.ListProjectUserOptions) ([]*gitlab.ProjectUser, error)
+	MockCurrentUser        func() (*gitlab.User, error)
+	MockCurrentTokenScopes func() ([]string, error)
 }
 
 func (m *MockUserClient) Users(opt *gitlab.ListUsersOptions) ([]*gitlab.User, error) {
@@ -47,3 +77,11 @@ func (m *MockUserClient) Users(opt *gitlab.ListUsersOptions) ([]*gitlab.User, er
 func (m *MockUserClient) ProjectUsers(repositoryName string, opt *gitlab.ListProjectUserOptions) ([]*gitlab.ProjectUser, error) {
 	return m.MockProjectUsers(repositoryName, opt)
 }
+
+func (m *MockUserClient) CurrentUser() (*gitlab.User, error) {
+	return m.MockCurrentUser()
+}
+
+func (m *MockUserClient) CurrentTokenScopes() ([]string, error) {
+	return m.MockCurrentTokenScopes()
+}
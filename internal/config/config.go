@@ -1,34 +1,69 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
-	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
+	"strings"
 	"syscall"
 
+	shellquote "github.com/kballard/go-shellquote"
 	yaml "gopkg.in/yaml.v2"
 )
 
-var configFilePath = getXDGConfigPath(runtime.GOOS)
+// EnvConfig is the environment variable to use another config file.
+const EnvConfig = "LAB_CONFIG"
+
+var configFilePath = defaultConfigPath(runtime.GOOS)
+
+// SetPath changes the config file to load and save, e.g. by the "--config" option.
+func SetPath(path string) {
+	configFilePath = path
+}
+
+var readOnly bool
+
+// SetReadOnly keeps lab from writing the config by itself, e.g. by the "--no-input" option.
+// The config is not created, and an old config is migrated in memory only.
+func SetReadOnly(b bool) {
+	readOnly = b
+}
+
+// ReadOnly reports whether lab must not write the config by itself.
+func ReadOnly() bool {
+	return readOnly
+}
 
 type Config struct {
 	Version        int                `yaml:"version"`
 	Profiles       map[string]Profile `yaml:"profiles"`
-	DefalutProfile string             `yaml:"default_profile"`
+	DefaultProfile string             `yaml:"default_profile"`
+	// PreferredRemotes is the order of git remotes to find the target project, e.g. [upstream, origin]
+	PreferredRemotes []string `yaml:"preferred_remotes,omitempty"`
 }
 
 type Profile struct {
-	Token             string `yaml:"token"`
-	DefaultGroup      string `yaml:"default_group"`
-	DefaultProject    string `yaml:"default_project"`
-	DefaultAssigneeID int    `yaml:"default_assignee_id"`
+	Token             string `yaml:"token,omitempty"`
+	TokenCommand      string `yaml:"token_command,omitempty"`
+	TokenStore        string `yaml:"token_store,omitempty"`
+	OAuthClientID     string `yaml:"oauth_client_id,omitempty"`
+	RefreshToken      string `yaml:"refresh_token,omitempty"`
+	DefaultGroup      string `yaml:"default_group,omitempty"`
+	DefaultProject    string `yaml:"default_project,omitempty"`
+	DefaultAssigneeID int    `yaml:"default_assignee_id,omitempty"`
+	Settings          `yaml:",inline"`
 }
 
+// TokenStoreKeyring is the token_store value that keeps the token in the OS keyring
+const TokenStoreKeyring = "keyring"
+
 func NewConfig() *Config {
 	cfg := &Config{
+		Version:  CurrentVersion,
 		Profiles: map[string]Profile{},
 	}
 	return cfg
@@ -47,76 +82,96 @@ func (c *Config) Path() string {
 }
 
 func (c *Config) Read() (string, error) {
-	if err := os.MkdirAll(filepath.Dir(configFilePath), 0700); err != nil {
-		return "", fmt.Errorf("cannot create directory, %s", err)
+	b, err := readConfigFile()
+	if err != nil {
+		return "", err
 	}
+	return string(b), nil
+}
 
-	if !fileExists(configFilePath) {
-		_, err := os.Create(configFilePath)
+// readConfigFile reads the config file, that is created when it does not exist unless read-only.
+func readConfigFile() ([]byte, error) {
+	if readOnly {
+		b, err := ioutil.ReadFile(configFilePath)
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
 		if err != nil {
-			return "", fmt.Errorf("cannot create config, %s", err.Error())
+			return nil, fmt.Errorf("cannot read config, %s", err)
 		}
+		restrictFileMode(configFilePath)
+		return b, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(configFilePath), 0700); err != nil {
+		return nil, fmt.Errorf("cannot create directory, %s", err)
+	}
+
+	if err := createConfigFile(); err != nil {
+		return nil, err
 	}
+	restrictFileMode(configFilePath)
 
-	file, err := os.OpenFile(configFilePath, os.O_RDONLY, 0666)
+	file, err := os.OpenFile(configFilePath, os.O_RDONLY, 0600)
 	if err != nil {
-		return "", fmt.Errorf("cannot open config, %s", err)
+		return nil, fmt.Errorf("cannot open config, %s", err)
 	}
 	defer file.Close()
 
 	b, err := ioutil.ReadAll(file)
 	if err != nil {
-		return "", fmt.Errorf("cannot read config, %s", err)
+		return nil, fmt.Errorf("cannot read config, %s", err)
 	}
-
-	return string(b), nil
+	return b, nil
 }
 
 func (c *Config) Load() error {
-	if err := os.MkdirAll(filepath.Dir(configFilePath), 0700); err != nil {
-		return fmt.Errorf("cannot create directory, %s", err)
+	b, err := readConfigFile()
+	if err != nil {
+		return err
 	}
 
-	if !fileExists(configFilePath) {
-		_, err := os.Create(configFilePath)
-		if err != nil {
-			return fmt.Errorf("cannot create config, %s", err.Error())
-		}
+	// A new config file has nothing to migrate
+	if len(bytes.TrimSpace(b)) == 0 {
+		return nil
 	}
 
-	file, err := os.OpenFile(configFilePath, os.O_RDONLY, 0666)
+	migrated, changed, err := migrate(b)
 	if err != nil {
-		return fmt.Errorf("cannot open config, %s", err)
+		return err
 	}
-	defer file.Close()
-
-	b, err := ioutil.ReadAll(file)
-	if err != nil {
-		return fmt.Errorf("cannot read config, %s", err)
+	if err = yaml.Unmarshal(migrated, c); err != nil {
+		return fmt.Errorf("failed unmarshal yaml. \nError: %s \nBuffer: %s", err, string(migrated))
 	}
 
-	if err = yaml.Unmarshal(b, c); err != nil {
-		return fmt.Errorf("failed unmarshal yaml. \nError: %s \nBuffer: %s", err, string(b))
+	// The migrated document is written instead of the Config, so that the unknown keys are kept
+	if changed && !readOnly {
+		if _, err := backup(configFilePath, b); err != nil {
+			return err
+		}
+		if err := writeFileAtomic(configFilePath, migrated); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 func (c *Config) Save() error {
-	file, err := os.OpenFile(configFilePath, os.O_WRONLY, 0666)
-	if err != nil {
-		return fmt.Errorf("cannot open file, %s", err)
-	}
-	defer file.Close()
-
 	out, err := yaml.Marshal(c)
 	if err != nil {
 		return fmt.Errorf("Failed marshal config. Error: %v", err)
 	}
+	return writeFileAtomic(configFilePath, out)
+}
 
-	if _, err = io.WriteString(file, string(out)); err != nil {
-		return fmt.Errorf("Failed write config file. Error: %s", err)
+// Domains returns the domains of the profiles in sorted order.
+func (c *Config) Domains() []string {
+	domains := make([]string, 0, len(c.Profiles))
+	for domain := range c.Profiles {
+		domains = append(domains, domain)
 	}
-	return nil
+	sort.Strings(domains)
+	return domains
 }
 
 func (c *Config) GetProfile(domain string) (*Profile, error) {
@@ -128,7 +183,7 @@ func (c *Config) GetProfile(domain string) (*Profile, error) {
 }
 
 func (c *Config) GetDefaultProfile() *Profile {
-	profile, _ := c.GetProfile(c.DefalutProfile)
+	profile, _ := c.GetProfile(c.DefaultProfile)
 	return profile
 }
 
@@ -144,20 +199,151 @@ func (c *Config) HasDomain(domain string) bool {
 	return true
 }
 
-func (c *Config) GetToken(domain string) string {
-	profile, _ := c.GetProfile(domain)
-	return profile.Token
+// GetToken returns the private token of the domain.
+// The token is resolved in order of token_command, keyring and plain token.
+func (c *Config) GetToken(domain string) (string, error) {
+	profile, err := c.GetProfile(domain)
+	if err != nil {
+		return "", err
+	}
+	return profile.ResolveToken(domain)
+}
+
+// SetToken stores the private token of the domain.
+// The token is written to the keyring instead of the config file when token_store is keyring.
+func (c *Config) SetToken(domain, token string) error {
+	profile, err := c.GetProfile(domain)
+	if err != nil {
+		return err
+	}
+
+	if profile.TokenStore == TokenStoreKeyring {
+		if err := keyring.Set(domain, token); err != nil {
+			return err
+		}
+		profile.Token = ""
+	} else {
+		profile.Token = token
+	}
+	c.SetProfile(domain, *profile)
+	return nil
+}
+
+// SetRefreshToken stores the OAuth refresh token of the domain next to the access token.
+func (c *Config) SetRefreshToken(domain, token string) error {
+	profile, err := c.GetProfile(domain)
+	if err != nil {
+		return err
+	}
+
+	if profile.TokenStore == TokenStoreKeyring {
+		if err := keyring.Set(refreshTokenKey(domain), token); err != nil {
+			return err
+		}
+		profile.RefreshToken = ""
+	} else {
+		profile.RefreshToken = token
+	}
+	c.SetProfile(domain, *profile)
+	return nil
+}
+
+func (c *Config) GetRefreshToken(domain string) (string, error) {
+	profile, err := c.GetProfile(domain)
+	if err != nil {
+		return "", err
+	}
+	if profile.TokenStore == TokenStoreKeyring {
+		return keyring.Get(refreshTokenKey(domain))
+	}
+	return profile.RefreshToken, nil
+}
+
+func refreshTokenKey(domain string) string {
+	return domain + "#refresh_token"
 }
 
-func (c *Config) SetToken(domain, token string) {
-	profile, _ := c.GetProfile(domain)
-	profile.Token = token
+// DeleteToken removes the private token of the domain from the config and the keyring.
+func (c *Config) DeleteToken(domain string) error {
+	profile, err := c.GetProfile(domain)
+	if err != nil {
+		return err
+	}
+
+	if profile.TokenStore == TokenStoreKeyring {
+		if err := keyring.Delete(domain); err != nil {
+			return err
+		}
+		if profile.IsOAuth() {
+			if err := keyring.Delete(refreshTokenKey(domain)); err != nil {
+				return err
+			}
+		}
+	}
+	profile.Token = ""
+	profile.RefreshToken = ""
 	c.SetProfile(domain, *profile)
+	return nil
+}
+
+// IsOAuth reports whether the token of the profile is an OAuth access token.
+func (p *Profile) IsOAuth() bool {
+	return p.OAuthClientID != ""
+}
+
+// HasToken reports whether the profile has a source of the token, without running token_command or reading the keyring.
+func (p *Profile) HasToken() bool {
+	return p.Token != "" || p.TokenCommand != "" || p.TokenStore != ""
+}
+
+func (p *Profile) ResolveToken(domain string) (string, error) {
+	if p.TokenCommand != "" {
+		return runTokenCommand(p.TokenCommand)
+	}
+	if p.TokenStore == TokenStoreKeyring {
+		return keyring.Get(domain)
+	}
+	if p.TokenStore != "" {
+		return "", fmt.Errorf("unknown token_store, %s", p.TokenStore)
+	}
+	return p.Token, nil
+}
+
+func runTokenCommand(command string) (string, error) {
+	args, err := shellquote.Split(command)
+	if err != nil || len(args) == 0 {
+		return "", fmt.Errorf("invalid token_command, %s", command)
+	}
+
+	var stderr bytes.Buffer
+	c := execCommand(args[0], args[1:]...)
+	c.Stdin = os.Stdin
+	c.Stderr = &stderr
+	out, err := c.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed token_command [%s], %s %s", command, err, strings.TrimSpace(stderr.String()))
+	}
+
+	// Use the first line like git credential helpers, e.g. "pass show"
+	token := strings.TrimSpace(strings.SplitN(string(out), "\n", 2)[0])
+	if token == "" {
+		return "", fmt.Errorf("token_command [%s] returned empty token", command)
+	}
+	return token, nil
+}
+
+func defaultConfigPath(goos string) string {
+	if path := os.Getenv(EnvConfig); path != "" {
+		return path
+	}
+	return getXDGConfigPath(goos)
 }
 
 func getXDGConfigPath(goos string) string {
 	var dir string
-	if goos == "windows" {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		dir = filepath.Join(xdg, "lab")
+	} else if goos == "windows" {
 		dir = os.Getenv("APPDATA")
 		if dir == "" {
 			dir = filepath.Join(os.Getenv("USERPROFILE"), "Application Data", "lab")
@@ -169,6 +355,27 @@ func getXDGConfigPath(goos string) string {
 	return filepath.Join(dir, "config.yml")
 }
 
+func createConfigFile() error {
+	if fileExists(configFilePath) {
+		return nil
+	}
+	file, err := os.OpenFile(configFilePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("cannot create config, %s", err.Error())
+	}
+	return file.Close()
+}
+
+// restrictFileMode makes the config readable only by the owner, because it can have the token in plain text.
+// The config written by an old lab is readable by the others. It is best effort, e.g. for the config of another user.
+func restrictFileMode(path string) {
+	info, err := os.Stat(path)
+	if err != nil || info.Mode().Perm()&0077 == 0 {
+		return
+	}
+	os.Chmod(path, info.Mode().Perm()&0700)
+}
+
 func fileExists(filename string) bool {
 	_, err := os.Stat(filename)
 
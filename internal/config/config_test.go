@@ -3,6 +3,8 @@ package config
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -35,6 +37,13 @@ func getTestConfigContent(fpath string) string {
 	return string(b)
 }
 
+func removeTestConfig(fpath string) {
+	backups, _ := filepath.Glob(fpath + ".*.bak")
+	for _, f := range append(backups, fpath) {
+		os.Remove(f)
+	}
+}
+
 func TestConfigV2_Load(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -46,8 +55,9 @@ func TestConfigV2_Load(t *testing.T) {
 			name:           "empty",
 			configContents: "",
 			want: &Config{
+				Version:        CurrentVersion,
 				Profiles:       map[string]Profile{},
-				DefalutProfile: "",
+				DefaultProfile: "",
 			},
 			wantErr: false,
 		},
@@ -82,8 +92,8 @@ version: 1
 						DefaultAssigneeID: 2,
 					},
 				},
-				DefalutProfile: "default_profile",
-				Version:        1,
+				DefaultProfile: "default_profile",
+				Version:        CurrentVersion,
 			},
 			wantErr: false,
 		},
@@ -91,7 +101,7 @@ version: 1
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			configFilePath = setupTestConfig(tt.configContents)
-			defer os.Remove(configFilePath)
+			defer removeTestConfig(configFilePath)
 
 			c := NewConfig()
 			if err := c.Load(); (err != nil) != tt.wantErr {
@@ -108,7 +118,7 @@ version: 1
 func TestConfigV2_Save(t *testing.T) {
 	type fields struct {
 		Profiles       map[string]Profile
-		DefalutProfile string
+		DefaultProfile string
 		Version        int
 	}
 	tests := []struct {
@@ -145,7 +155,7 @@ default_profile: ""
 						DefaultAssigneeID: 2,
 					},
 				},
-				DefalutProfile: "default_profile",
+				DefaultProfile: "default_profile",
 				Version:        1,
 			},
 			want: `version: 1
@@ -172,7 +182,7 @@ default_profile: default_profile
 
 			c := &Config{
 				Profiles:       tt.fields.Profiles,
-				DefalutProfile: tt.fields.DefalutProfile,
+				DefaultProfile: tt.fields.DefaultProfile,
 				Version:        tt.fields.Version,
 			}
 
@@ -190,9 +200,11 @@ default_profile: default_profile
 func Test_getXDGConfigPath(t *testing.T) {
 	os.Setenv("APPDATA", "appdata")
 	os.Setenv("HOME", "home")
+	os.Unsetenv("XDG_CONFIG_HOME")
 	tests := []struct {
 		name string
 		goos string
+		xdg  string
 		want string
 	}{
 		{
@@ -205,12 +217,205 @@ func Test_getXDGConfigPath(t *testing.T) {
 			goos: "linux",
 			want: "home/.config/lab/config.yml",
 		},
+		{
+			name: "xdg config home",
+			goos: "linux",
+			xdg:  "xdg",
+			want: "xdg/lab/config.yml",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("XDG_CONFIG_HOME", tt.xdg)
+			defer os.Unsetenv("XDG_CONFIG_HOME")
 			if got := getXDGConfigPath(tt.goos); got != tt.want {
 				t.Errorf("getXDGConfigPath() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func Test_defaultConfigPath(t *testing.T) {
+	os.Setenv("HOME", "home")
+	os.Unsetenv("XDG_CONFIG_HOME")
+	os.Setenv(EnvConfig, "/tmp/lab.yml")
+	defer os.Unsetenv(EnvConfig)
+	if got := defaultConfigPath("linux"); got != "/tmp/lab.yml" {
+		t.Errorf("defaultConfigPath() = %v, want %v", got, "/tmp/lab.yml")
+	}
+
+	os.Unsetenv(EnvConfig)
+	if got := defaultConfigPath("linux"); got != "home/.config/lab/config.yml" {
+		t.Errorf("defaultConfigPath() = %v, want %v", got, "home/.config/lab/config.yml")
+	}
+}
+
+func TestConfig_SaveFileMode(t *testing.T) {
+	configFilePath = setupTestConfig("")
+	defer os.Remove(configFilePath)
+	if err := os.Chmod(configFilePath, 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewConfig()
+	if err := c.Save(); err != nil {
+		t.Fatalf("Config.Save() error = %v", err)
+	}
+
+	info, err := os.Stat(configFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := info.Mode().Perm(); got != 0600 {
+		t.Errorf("Config.Save() file mode = %o, want %o", got, 0600)
+	}
+}
+
+func TestConfig_SaveCreatesDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lab")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	configFilePath = filepath.Join(dir, "lab", "config.yml")
+	if err := NewConfig().Save(); err != nil {
+		t.Fatalf("Config.Save() error = %v", err)
+	}
+	if _, err := os.Stat(configFilePath); err != nil {
+		t.Errorf("Config.Save() did not create the config, %s", err)
+	}
+}
+
+func TestConfig_LoadFileMode(t *testing.T) {
+	configFilePath = setupTestConfig("version: 2\n")
+	defer os.Remove(configFilePath)
+
+	for _, ro := range []bool{false, true} {
+		if err := os.Chmod(configFilePath, 0644); err != nil {
+			t.Fatal(err)
+		}
+		SetReadOnly(ro)
+		err := NewConfig().Load()
+		SetReadOnly(false)
+		if err != nil {
+			t.Fatalf("Config.Load() error = %v", err)
+		}
+
+		info, err := os.Stat(configFilePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := info.Mode().Perm(); got != 0600 {
+			t.Errorf("Config.Load() read-only %v file mode = %o, want %o", ro, got, 0600)
+		}
+	}
+}
+
+func TestConfig_LoadReadOnly(t *testing.T) {
+	SetReadOnly(true)
+	defer SetReadOnly(false)
+
+	contents := "version: 1\nprofiles:\n  gitlab.com:\n    token: token1\n"
+	configFilePath = setupTestConfig(contents)
+	defer removeTestConfig(configFilePath)
+
+	cfg, err := GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Version != CurrentVersion || cfg.Profiles["gitlab.com"].Token != "token1" {
+		t.Errorf("config is not migrated, %+v", cfg)
+	}
+	if got := getTestConfigContent(configFilePath); got != contents {
+		t.Errorf("config is changed\n%s", got)
+	}
+	if backups, _ := filepath.Glob(configFilePath + ".*.bak"); len(backups) != 0 {
+		t.Errorf("backup is created, %v", backups)
+	}
+
+	// A missing config is not created
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	configFilePath = filepath.Join(dir, "lab", "config.yml")
+	if _, err := GetConfig(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Dir(configFilePath)); !os.IsNotExist(err) {
+		t.Errorf("config directory is created, %v", err)
+	}
+}
+
+func TestProfile_ResolveToken(t *testing.T) {
+	keyring = &MockKeyring{Tokens: map[string]string{"gitlab.com": "keyringtoken"}}
+	defer func() { keyring = NewSystemKeyring(runtime.GOOS) }()
+
+	tests := []struct {
+		name    string
+		profile *Profile
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "plain token",
+			profile: &Profile{Token: "token"},
+			want:    "token",
+		},
+		{
+			name:    "token command",
+			profile: &Profile{Token: "token", TokenCommand: "echo 'commandtoken\nsecond line'"},
+			want:    "commandtoken",
+		},
+		{
+			name:    "token command failed",
+			profile: &Profile{TokenCommand: "false"},
+			wantErr: true,
+		},
+		{
+			name:    "keyring",
+			profile: &Profile{Token: "token", TokenStore: TokenStoreKeyring},
+			want:    "keyringtoken",
+		},
+		{
+			name:    "unknown token store",
+			profile: &Profile{TokenStore: "unknown"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.profile.ResolveToken("gitlab.com")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Profile.ResolveToken() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Profile.ResolveToken() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_SetTokenKeyring(t *testing.T) {
+	mock := &MockKeyring{Tokens: map[string]string{}}
+	keyring = mock
+	defer func() { keyring = NewSystemKeyring(runtime.GOOS) }()
+
+	c := &Config{
+		Profiles: map[string]Profile{
+			"gitlab.com": Profile{TokenStore: TokenStoreKeyring},
+		},
+	}
+	if err := c.SetToken("gitlab.com", "secret"); err != nil {
+		t.Fatalf("Config.SetToken() error = %v", err)
+	}
+	if got := c.Profiles["gitlab.com"].Token; got != "" {
+		t.Errorf("token written to config, %v", got)
+	}
+	if got := mock.Tokens["gitlab.com"]; got != "secret" {
+		t.Errorf("keyring token = %v, want %v", got, "secret")
+	}
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+const keyringService = "lab"
+
+// Keyring stores private tokens outside of the config file.
+type Keyring interface {
+	Get(domain string) (string, error)
+	Set(domain, token string) error
+	Delete(domain string) error
+}
+
+// SystemKeyring uses the credential store of the OS.
+// "secret-tool" (libsecret) on Linux and "security" (Keychain) on macOS.
+type SystemKeyring struct {
+	goos string
+}
+
+func NewSystemKeyring(goos string) Keyring {
+	return &SystemKeyring{goos: goos}
+}
+
+func (k *SystemKeyring) Get(domain string) (string, error) {
+	var args []string
+	switch k.goos {
+	case "darwin":
+		args = []string{"security", "find-generic-password", "-s", keyringService, "-a", domain, "-w"}
+	case "windows":
+		return "", fmt.Errorf("keyring is not supported on %s", k.goos)
+	default:
+		args = []string{"secret-tool", "lookup", "service", keyringService, "domain", domain}
+	}
+
+	out, err := keyringCommand(args, "")
+	if err != nil {
+		return "", fmt.Errorf("cannot read token from keyring, %s", err)
+	}
+	token := strings.TrimSpace(out)
+	if token == "" {
+		return "", fmt.Errorf("not found token in keyring, [%s]", domain)
+	}
+	return token, nil
+}
+
+func (k *SystemKeyring) Set(domain, token string) error {
+	var args []string
+	var stdin string
+	switch k.goos {
+	case "darwin":
+		// The interactive mode reads the command from stdin, so that the token is not in the arguments seen by ps
+		args = []string{"security", "-i"}
+		stdin = fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n", securityQuote(keyringService), securityQuote(domain), securityQuote(token))
+	case "windows":
+		return fmt.Errorf("keyring is not supported on %s", k.goos)
+	default:
+		args = []string{"secret-tool", "store", "--label", "lab token for " + domain, "service", keyringService, "domain", domain}
+		stdin = token
+	}
+
+	if _, err := keyringCommand(args, stdin); err != nil {
+		return fmt.Errorf("cannot write token to keyring, %s", err)
+	}
+	return nil
+}
+
+func (k *SystemKeyring) Delete(domain string) error {
+	var args []string
+	switch k.goos {
+	case "darwin":
+		args = []string{"security", "delete-generic-password", "-s", keyringService, "-a", domain}
+	case "windows":
+		return fmt.Errorf("keyring is not supported on %s", k.goos)
+	default:
+		args = []string{"secret-tool", "clear", "service", keyringService, "domain", domain}
+	}
+
+	if _, err := keyringCommand(args, ""); err != nil {
+		return fmt.Errorf("cannot delete token from keyring, %s", err)
+	}
+	return nil
+}
+
+// securityQuote quotes the argument of the command for the interactive mode of "security".
+func securityQuote(arg string) string {
+	arg = strings.Replace(arg, `\`, `\\`, -1)
+	arg = strings.Replace(arg, `"`, `\"`, -1)
+	return `"` + arg + `"`
+}
+
+func keyringCommand(args []string, stdin string) (string, error) {
+	c := execCommand(args[0], args[1:]...)
+	if stdin != "" {
+		c.Stdin = strings.NewReader(stdin)
+	}
+	var stderr bytes.Buffer
+	c.Stderr = &stderr
+	out, err := c.Output()
+	if err != nil {
+		return "", fmt.Errorf("%s, %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
+
+// For os/exec test
+var execCommand = exec.Command
+
+var keyring = NewSystemKeyring(runtime.GOOS)
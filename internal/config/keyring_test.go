@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type MockKeyring struct {
+	Tokens map[string]string
+}
+
+func (m *MockKeyring) Get(domain string) (string, error) {
+	token, ok := m.Tokens[domain]
+	if !ok {
+		return "", fmt.Errorf("not found token in keyring, [%s]", domain)
+	}
+	return token, nil
+}
+
+func (m *MockKeyring) Set(domain, token string) error {
+	m.Tokens[domain] = token
+	return nil
+}
+
+func (m *MockKeyring) Delete(domain string) error {
+	delete(m.Tokens, domain)
+	return nil
+}
+
+func TestSystemKeyring_SetDarwin(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lab")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	stdinPath := filepath.Join(dir, "stdin")
+
+	var gotArgs []string
+	execCommand = func(name string, args ...string) *exec.Cmd {
+		gotArgs = append([]string{name}, args...)
+		return exec.Command("sh", "-c", "cat > "+stdinPath)
+	}
+	defer func() { execCommand = exec.Command }()
+
+	k := NewSystemKeyring("darwin")
+	if err := k.Set("gitlab.com", `se"cret`); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if strings.Contains(strings.Join(gotArgs, " "), "cret") {
+		t.Errorf("token is in the arguments, %v", gotArgs)
+	}
+	stdin, err := ioutil.ReadFile(stdinPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `add-generic-password -U -s "lab" -a "gitlab.com" -w "se\"cret"` + "\n"
+	if string(stdin) != want {
+		t.Errorf("stdin = %q, want %q", stdin, want)
+	}
+}
@@ -0,0 +1,249 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// These are the keys outside of the profiles.
+const (
+	KeyDefaultProfile   = "default_profile"
+	KeyPreferredRemotes = "preferred_remotes"
+)
+
+// IsGlobalKey reports whether the key is outside of the profiles.
+func IsGlobalKey(key string) bool {
+	return key == KeyDefaultProfile || key == KeyPreferredRemotes
+}
+
+// ProfileKeys are the keys of a profile that can be read and written by "lab config get/set".
+var ProfileKeys = []string{
+	"token",
+	"token_command",
+	"token_store",
+	"oauth_client_id",
+	"default_group",
+	"default_project",
+	"default_assignee_id",
+	"target_branch",
+	"default_labels",
+	"mr_template",
+	"issue_template",
+	"remove_source_branch",
+	"squash",
+}
+
+// Get returns the value of the key.
+// The domain is ignored for the keys outside of the profiles.
+func (c *Config) Get(domain, key string) (string, error) {
+	switch key {
+	case KeyDefaultProfile:
+		return c.DefaultProfile, nil
+	case KeyPreferredRemotes:
+		return strings.Join(c.PreferredRemotes, ","), nil
+	}
+	profile, err := c.GetProfile(domain)
+	if err != nil {
+		return "", err
+	}
+	return profile.Get(key)
+}
+
+// Set changes the value of the key.
+// The token is written through SetToken to keep it in the keyring when token_store is keyring.
+func (c *Config) Set(domain, key, value string) error {
+	switch key {
+	case KeyDefaultProfile:
+		return c.UseProfile(value)
+	case KeyPreferredRemotes:
+		c.PreferredRemotes = splitList(value)
+		return nil
+	}
+	if key == "token" {
+		return c.SetToken(domain, value)
+	}
+	profile, err := c.GetProfile(domain)
+	if err != nil {
+		return err
+	}
+	if err := profile.Set(key, value); err != nil {
+		return err
+	}
+	c.SetProfile(domain, *profile)
+	return nil
+}
+
+// AddProfile adds an empty profile of the domain.
+func (c *Config) AddProfile(domain string) error {
+	if domain == "" {
+		return fmt.Errorf("profile name is empty")
+	}
+	if c.HasDomain(domain) {
+		return fmt.Errorf("profile already exists, [%s]", domain)
+	}
+	if c.Profiles == nil {
+		c.Profiles = map[string]Profile{}
+	}
+	c.SetProfile(domain, Profile{})
+	return nil
+}
+
+// UseProfile makes the profile of the domain the default.
+func (c *Config) UseProfile(domain string) error {
+	if !c.HasDomain(domain) {
+		return fmt.Errorf("not found profile, [%s]. Please check config", domain)
+	}
+	c.DefaultProfile = domain
+	return nil
+}
+
+// Validate checks the config file against the schema, and the values of the loaded config.
+func (c *Config) Validate() []error {
+	errs := []error{}
+
+	b, err := ioutil.ReadFile(c.Path())
+	if err != nil {
+		return append(errs, fmt.Errorf("cannot read config, %s", err))
+	}
+	if err := yaml.UnmarshalStrict(b, &Config{}); err != nil {
+		errs = append(errs, fmt.Errorf("invalid schema, %s", err))
+	}
+
+	if c.DefaultProfile != "" && !c.HasDomain(c.DefaultProfile) {
+		errs = append(errs, fmt.Errorf("default_profile [%s] is not found in profiles", c.DefaultProfile))
+	}
+	for domain, profile := range c.Profiles {
+		if profile.TokenStore != "" && profile.TokenStore != TokenStoreKeyring {
+			errs = append(errs, fmt.Errorf("unknown token_store [%s] in profile [%s]", profile.TokenStore, domain))
+		}
+		if profile.TokenCommand != "" && profile.Token != "" {
+			errs = append(errs, fmt.Errorf("token is ignored because token_command is set in profile [%s]", domain))
+		}
+	}
+	return errs
+}
+
+func (p *Profile) Get(key string) (string, error) {
+	switch key {
+	case "token":
+		return p.Token, nil
+	case "token_command":
+		return p.TokenCommand, nil
+	case "token_store":
+		return p.TokenStore, nil
+	case "oauth_client_id":
+		return p.OAuthClientID, nil
+	case "default_group":
+		return p.DefaultGroup, nil
+	case "default_project":
+		return p.DefaultProject, nil
+	case "default_assignee_id":
+		return intString(p.DefaultAssigneeID), nil
+	case "target_branch":
+		return p.TargetBranch, nil
+	case "default_labels":
+		return strings.Join(p.DefaultLabels, ","), nil
+	case "mr_template":
+		return p.MRTemplate, nil
+	case "issue_template":
+		return p.IssueTemplate, nil
+	case "remove_source_branch":
+		return boolString(p.RemoveSourceBranch), nil
+	case "squash":
+		return boolString(p.Squash), nil
+	}
+	return "", unknownKeyError(key)
+}
+
+// Set changes the value of the key. An empty value unsets the key.
+func (p *Profile) Set(key, value string) error {
+	switch key {
+	case "token":
+		p.Token = value
+	case "token_command":
+		p.TokenCommand = value
+	case "token_store":
+		if value != "" && value != TokenStoreKeyring {
+			return fmt.Errorf("unknown token_store, %s", value)
+		}
+		p.TokenStore = value
+	case "oauth_client_id":
+		p.OAuthClientID = value
+	case "default_group":
+		p.DefaultGroup = value
+	case "default_project":
+		p.DefaultProject = value
+	case "default_assignee_id":
+		i, err := parseInt(key, value)
+		if err != nil {
+			return err
+		}
+		p.DefaultAssigneeID = i
+	case "target_branch":
+		p.TargetBranch = value
+	case "default_labels":
+		p.DefaultLabels = splitList(value)
+	case "mr_template":
+		p.MRTemplate = value
+	case "issue_template":
+		p.IssueTemplate = value
+	case "remove_source_branch":
+		b, err := parseBool(key, value)
+		if err != nil {
+			return err
+		}
+		p.RemoveSourceBranch = b
+	case "squash":
+		b, err := parseBool(key, value)
+		if err != nil {
+			return err
+		}
+		p.Squash = b
+	default:
+		return unknownKeyError(key)
+	}
+	return nil
+}
+
+func unknownKeyError(key string) error {
+	return fmt.Errorf("unknown key, %s. Available keys: %s, %s, %s", key, KeyDefaultProfile, KeyPreferredRemotes, strings.Join(ProfileKeys, ", "))
+}
+
+func parseInt(key, value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a number, %s", key, value)
+	}
+	return i, nil
+}
+
+func parseBool(key, value string) (*bool, error) {
+	if value == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be true or false, %s", key, value)
+	}
+	return &b, nil
+}
+
+func splitList(value string) []string {
+	list := []string{}
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	if len(list) == 0 {
+		return nil
+	}
+	return list
+}
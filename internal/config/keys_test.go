@@ -0,0 +1,111 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConfig_GetSet(t *testing.T) {
+	cfg := &Config{
+		Profiles: map[string]Profile{
+			"gitlab.com": {Token: "token"},
+		},
+		DefaultProfile: "gitlab.com",
+	}
+
+	tests := []struct {
+		key     string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{key: "default_group", value: "hoge", want: "hoge"},
+		{key: "default_assignee_id", value: "123", want: "123"},
+		{key: "default_assignee_id", value: "abc", wantErr: true},
+		{key: "default_labels", value: "bug, team-a,", want: "bug,team-a"},
+		{key: "squash", value: "true", want: "true"},
+		{key: "squash", value: "", want: ""},
+		{key: "squash", value: "yes", wantErr: true},
+		{key: "token_store", value: "file", wantErr: true},
+		{key: "token", value: "newtoken", want: "newtoken"},
+		{key: "unknown", value: "a", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+			err := cfg.Set("gitlab.com", tt.key, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Set() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := cfg.Get("gitlab.com", tt.key)
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Get() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_AddAndUseProfile(t *testing.T) {
+	cfg := NewConfig()
+	if err := cfg.UseProfile("gitlab.com"); err == nil {
+		t.Error("UseProfile() want error for unknown profile")
+	}
+	if err := cfg.AddProfile("gitlab.com"); err != nil {
+		t.Fatalf("AddProfile() error = %v", err)
+	}
+	if err := cfg.AddProfile("gitlab.com"); err == nil {
+		t.Error("AddProfile() want error for existing profile")
+	}
+	if err := cfg.Set("", KeyDefaultProfile, "gitlab.com"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if cfg.DefaultProfile != "gitlab.com" {
+		t.Errorf("default profile is not changed, %s", cfg.DefaultProfile)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	content := `version: 2
+default_profile: gitlab.example.com
+profiles:
+  gitlab.com:
+    token: token
+    token_command: pass show gitlab
+    unknown_key: value
+`
+	configFilePath = setupTestConfig(content)
+	cfg := NewConfig()
+	if err := cfg.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, err := range cfg.Validate() {
+		got = append(got, err.Error())
+	}
+	want := []string{
+		"invalid schema, yaml: unmarshal errors:\n  line 7: field unknown_key not found in type config.Profile",
+		"default_profile [gitlab.example.com] is not found in profiles",
+		"token is ignored because token_command is set in profile [gitlab.com]",
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Invalid result: (-got +want)\n%s", diff)
+	}
+}
+
+func TestConfig_Domains(t *testing.T) {
+	cfg := &Config{Profiles: map[string]Profile{
+		"gitlab.example.com": {},
+		"gitlab.com":         {},
+	}}
+	want := []string{"gitlab.com", "gitlab.example.com"}
+	if diff := cmp.Diff(cfg.Domains(), want); diff != "" {
+		t.Errorf("Domains() differs: (-got +want)\n%s", diff)
+	}
+}
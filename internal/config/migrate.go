@@ -0,0 +1,147 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// CurrentVersion is the version of the config written by this lab.
+const CurrentVersion = 2
+
+// migration upgrades the config document to the version.
+// The document is the generic YAML map, so that a step can rename and move keys
+// that the Config struct of this version no longer knows.
+type migration struct {
+	version int
+	migrate func(doc yaml.MapSlice) (yaml.MapSlice, error)
+}
+
+// migrations must be sorted by version.
+var migrations = []migration{
+	{version: 2, migrate: migrateToV2},
+}
+
+// For backup file name test
+var now = time.Now
+
+// migrateToV2 only bumps the version. No key is renamed in version 2,
+// and the values are kept as they are, even the empty ones and the unknown keys.
+func migrateToV2(doc yaml.MapSlice) (yaml.MapSlice, error) {
+	return doc, nil
+}
+
+// migrate upgrades the config file contents to CurrentVersion.
+// It reports whether the contents are changed.
+func migrate(b []byte) ([]byte, bool, error) {
+	doc := yaml.MapSlice{}
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, false, fmt.Errorf("failed unmarshal yaml. \nError: %s \nBuffer: %s", err, string(b))
+	}
+
+	version, err := docVersion(doc)
+	if err != nil {
+		return nil, false, err
+	}
+	if version > CurrentVersion {
+		return nil, false, fmt.Errorf("config version %d is newer than this lab supports (%d). Please upgrade lab", version, CurrentVersion)
+	}
+	if version == CurrentVersion {
+		return b, false, nil
+	}
+
+	for _, m := range migrations {
+		if m.version <= version {
+			continue
+		}
+		doc, err = m.migrate(doc)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed migrate config to version %d, %s", m.version, err)
+		}
+		doc = setDocVersion(doc, m.version)
+	}
+
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, false, fmt.Errorf("Failed marshal config. Error: %v", err)
+	}
+	return out, true, nil
+}
+
+func docVersion(doc yaml.MapSlice) (int, error) {
+	for _, item := range doc {
+		if item.Key != "version" {
+			continue
+		}
+		if item.Value == nil {
+			return 0, nil
+		}
+		version, ok := item.Value.(int)
+		if !ok {
+			return 0, fmt.Errorf("invalid config version, %v", item.Value)
+		}
+		return version, nil
+	}
+	return 0, nil
+}
+
+func setDocVersion(doc yaml.MapSlice, version int) yaml.MapSlice {
+	for i, item := range doc {
+		if item.Key == "version" {
+			doc[i].Value = version
+			return doc
+		}
+	}
+	return append(yaml.MapSlice{{Key: "version", Value: version}}, doc...)
+}
+
+// backup copies the config file before the migration, e.g. config.yml.20190102150405.bak
+func backup(path string, b []byte) (string, error) {
+	backupPath := fmt.Sprintf("%s.%s.bak", path, now().Format("20060102150405"))
+	if err := ioutil.WriteFile(backupPath, b, 0600); err != nil {
+		return "", fmt.Errorf("cannot backup config, %s", err)
+	}
+	return backupPath, nil
+}
+
+// writeFileAtomic writes to a temporary file in the same directory and renames it,
+// so that the config is never left half written.
+// A symbolic link is followed, so that the link is kept and its target is replaced.
+func writeFileAtomic(path string, b []byte) error {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("cannot create directory, %s", err)
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("cannot create temporary file, %s", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("cannot change file mode, %s", err)
+	}
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return fmt.Errorf("Failed write config file. Error: %s", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("Failed write config file. Error: %s", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("Failed write config file. Error: %s", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("cannot replace config, %s", err)
+	}
+	return nil
+}
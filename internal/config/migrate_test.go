@@ -0,0 +1,195 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_migrate(t *testing.T) {
+	tests := []struct {
+		name        string
+		contents    string
+		want        string
+		wantChanged bool
+		wantErr     bool
+	}{
+		{
+			name: "version 1",
+			contents: `version: 1
+profiles:
+  gitlab.com:
+    token: token1
+    default_group: ""
+    default_project: ""
+    default_assignee_id: 0
+default_profile: gitlab.com
+`,
+			want: `version: 2
+profiles:
+  gitlab.com:
+    token: token1
+    default_group: ""
+    default_project: ""
+    default_assignee_id: 0
+default_profile: gitlab.com
+`,
+			wantChanged: true,
+		},
+		{
+			name: "no version",
+			contents: `profiles:
+  gitlab.com:
+    token: token1
+default_profile: missing.com
+`,
+			want: `version: 2
+profiles:
+  gitlab.com:
+    token: token1
+default_profile: missing.com
+`,
+			wantChanged: true,
+		},
+		{
+			name: "current version",
+			contents: `version: 2
+profiles: {}
+`,
+			want: `version: 2
+profiles: {}
+`,
+			wantChanged: false,
+		},
+		{
+			name: "newer version",
+			contents: `version: 3
+profiles: {}
+`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed, err := migrate([]byte(tt.contents))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("migrate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("migrate() changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if diff := cmp.Diff(string(got), tt.want); diff != "" {
+				t.Errorf("migrate() differs: (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConfig_LoadMigrate(t *testing.T) {
+	contents := `version: 1
+profiles:
+  gitlab.com:
+    token: token1
+    default_group: ""
+    unknown_key: value
+default_profile: gitlab.com
+`
+	configFilePath = setupTestConfig(contents)
+	defer removeTestConfig(configFilePath)
+	now = func() time.Time { return time.Date(2019, 1, 2, 15, 4, 5, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	if _, err := GetConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	backup, err := ioutil.ReadFile(configFilePath + ".20190102150405.bak")
+	if err != nil {
+		t.Fatalf("backup is not created, %s", err)
+	}
+	if string(backup) != contents {
+		t.Errorf("backup differs from the original\n%s", backup)
+	}
+
+	want := `version: 2
+profiles:
+  gitlab.com:
+    token: token1
+    default_group: ""
+    unknown_key: value
+default_profile: gitlab.com
+`
+	if diff := cmp.Diff(getTestConfigContent(configFilePath), want); diff != "" {
+		t.Errorf("migrated config differs: (-got +want)\n%s", diff)
+	}
+}
+
+func TestConfig_LoadNewerVersion(t *testing.T) {
+	contents := "version: 99\nprofiles: {}\n"
+	configFilePath = setupTestConfig(contents)
+	defer removeTestConfig(configFilePath)
+
+	if _, err := GetConfig(); err == nil {
+		t.Fatal("GetConfig() want error for newer version")
+	}
+	if got := getTestConfigContent(configFilePath); got != contents {
+		t.Errorf("config is changed\n%s", got)
+	}
+	if backups, _ := filepath.Glob(configFilePath + ".*.bak"); len(backups) != 0 {
+		t.Errorf("backup is created, %v", backups)
+	}
+}
+
+func Test_writeFileAtomic(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "lab", "config.yml")
+	if err := writeFileAtomic(path, []byte("version: 2\n")); err != nil {
+		t.Fatal(err)
+	}
+	files, _ := ioutil.ReadDir(filepath.Dir(path))
+	if len(files) != 1 {
+		t.Errorf("temporary file is left, %d files", len(files))
+	}
+	if files[0].Mode().Perm() != 0600 {
+		t.Errorf("bad file mode, %o", files[0].Mode().Perm())
+	}
+}
+
+func Test_writeFileAtomicSymlink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	target := filepath.Join(dir, "dotfiles.yml")
+	if err := ioutil.WriteFile(target, []byte("version: 1\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "config.yml")
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writeFileAtomic(link, []byte("version: 2\n")); err != nil {
+		t.Fatal(err)
+	}
+	if fi, err := os.Lstat(link); err != nil || fi.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("symbolic link is replaced, %v", err)
+	}
+	if got := getTestConfigContent(target); got != "version: 2\n" {
+		t.Errorf("link target is not written, %q", got)
+	}
+}
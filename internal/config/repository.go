@@ -0,0 +1,136 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// RepositoryConfigFile is the per-repository config file placed at the git root.
+const RepositoryConfigFile = ".lab.yml"
+
+// Settings are the defaults for creating issues and merge requests.
+// They can be written in both a profile and the repository config.
+type Settings struct {
+	TargetBranch       string   `yaml:"target_branch,omitempty"`
+	DefaultLabels      []string `yaml:"default_labels,omitempty"`
+	MRTemplate         string   `yaml:"mr_template,omitempty"`
+	IssueTemplate      string   `yaml:"issue_template,omitempty"`
+	RemoveSourceBranch *bool    `yaml:"remove_source_branch,omitempty"`
+	Squash             *bool    `yaml:"squash,omitempty"`
+}
+
+// RepositoryConfig is shared by everyone working on the repository.
+type RepositoryConfig struct {
+	Settings          `yaml:",inline"`
+	DefaultAssigneeID int `yaml:"default_assignee_id,omitempty"`
+}
+
+// LoadRepositoryConfig reads the repository config in the root directory.
+// It returns nil when the file does not exist.
+func LoadRepositoryConfig(root string) (*RepositoryConfig, error) {
+	path := filepath.Join(root, RepositoryConfigFile)
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("cannot read %s, %s", path, err)
+	}
+
+	repo := &RepositoryConfig{}
+	if err := yaml.UnmarshalStrict(b, repo); err != nil {
+		return nil, fmt.Errorf("failed unmarshal %s. \nError: %s", path, err)
+	}
+	return repo, nil
+}
+
+// Merge returns a copy of the profile overridden by the repository config.
+func (r *RepositoryConfig) Merge(profile *Profile) *Profile {
+	merged := *profile
+	if r == nil {
+		return &merged
+	}
+	if r.TargetBranch != "" {
+		merged.TargetBranch = r.TargetBranch
+	}
+	if len(r.DefaultLabels) > 0 {
+		merged.DefaultLabels = r.DefaultLabels
+	}
+	if r.MRTemplate != "" {
+		merged.MRTemplate = r.MRTemplate
+	}
+	if r.IssueTemplate != "" {
+		merged.IssueTemplate = r.IssueTemplate
+	}
+	if r.RemoveSourceBranch != nil {
+		merged.RemoveSourceBranch = r.RemoveSourceBranch
+	}
+	if r.Squash != nil {
+		merged.Squash = r.Squash
+	}
+	if r.DefaultAssigneeID != 0 {
+		merged.DefaultAssigneeID = r.DefaultAssigneeID
+	}
+	return &merged
+}
+
+// EffectiveSetting is a merged setting value and where it came from.
+type EffectiveSetting struct {
+	Key    string
+	Value  string
+	Source string
+}
+
+// EffectiveSettings lists the settings of the profile merged with the repository config.
+func EffectiveSettings(domain string, profile *Profile, configPath string, repo *RepositoryConfig, repoPath string) []*EffectiveSetting {
+	profileSource := fmt.Sprintf("profile %s (%s)", domain, configPath)
+	repoSource := repoPath
+	if repo == nil {
+		repo = &RepositoryConfig{}
+	}
+
+	settings := []*EffectiveSetting{}
+	add := func(key, profileValue, repoValue string) {
+		s := &EffectiveSetting{Key: key, Value: "", Source: "not set"}
+		if profileValue != "" {
+			s.Value = profileValue
+			s.Source = profileSource
+		}
+		if repoValue != "" {
+			s.Value = repoValue
+			s.Source = repoSource
+		}
+		settings = append(settings, s)
+	}
+
+	add("target_branch", profile.TargetBranch, repo.TargetBranch)
+	add("default_labels", strings.Join(profile.DefaultLabels, ","), strings.Join(repo.DefaultLabels, ","))
+	add("mr_template", profile.MRTemplate, repo.MRTemplate)
+	add("issue_template", profile.IssueTemplate, repo.IssueTemplate)
+	add("remove_source_branch", boolString(profile.RemoveSourceBranch), boolString(repo.RemoveSourceBranch))
+	add("squash", boolString(profile.Squash), boolString(repo.Squash))
+	add("default_assignee_id", intString(profile.DefaultAssigneeID), intString(repo.DefaultAssigneeID))
+	add("default_group", profile.DefaultGroup, "")
+	add("default_project", profile.DefaultProject, "")
+	return settings
+}
+
+func boolString(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	return strconv.FormatBool(*b)
+}
+
+func intString(i int) string {
+	if i == 0 {
+		return ""
+	}
+	return strconv.Itoa(i)
+}
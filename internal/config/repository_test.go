@@ -0,0 +1,153 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoadRepositoryConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    *RepositoryConfig
+		wantErr bool
+	}{
+		{
+			name: "normal",
+			content: `target_branch: develop
+default_labels:
+- bug
+- team-a
+squash: true
+`,
+			want: &RepositoryConfig{
+				Settings: Settings{
+					TargetBranch:  "develop",
+					DefaultLabels: []string{"bug", "team-a"},
+					Squash:        boolPtr(true),
+				},
+			},
+		},
+		{
+			name:    "unknown key",
+			content: "target_brnach: develop\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "repo")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			if err := ioutil.WriteFile(filepath.Join(dir, RepositoryConfigFile), []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := LoadRepositoryConfig(dir)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadRepositoryConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("Invalid result: (-got +want)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoadRepositoryConfig_NotExist(t *testing.T) {
+	dir, err := ioutil.TempDir("", "repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	got, err := LoadRepositoryConfig(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != nil {
+		t.Errorf("want nil, got %v", got)
+	}
+}
+
+func TestRepositoryConfig_Merge(t *testing.T) {
+	profile := &Profile{
+		Token:             "token",
+		DefaultAssigneeID: 1,
+		Settings: Settings{
+			TargetBranch:  "master",
+			DefaultLabels: []string{"profile"},
+			Squash:        boolPtr(true),
+		},
+	}
+	repo := &RepositoryConfig{
+		Settings: Settings{
+			TargetBranch: "develop",
+			Squash:       boolPtr(false),
+		},
+	}
+
+	got := repo.Merge(profile)
+	want := &Profile{
+		Token:             "token",
+		DefaultAssigneeID: 1,
+		Settings: Settings{
+			TargetBranch:  "develop",
+			DefaultLabels: []string{"profile"},
+			Squash:        boolPtr(false),
+		},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Invalid result: (-got +want)\n%s", diff)
+	}
+	if profile.TargetBranch != "master" {
+		t.Errorf("profile is modified, %s", profile.TargetBranch)
+	}
+
+	var nilRepo *RepositoryConfig
+	if diff := cmp.Diff(nilRepo.Merge(profile), profile); diff != "" {
+		t.Errorf("Invalid result: (-got +want)\n%s", diff)
+	}
+}
+
+func TestEffectiveSettings(t *testing.T) {
+	profile := &Profile{
+		DefaultGroup: "group",
+		Settings: Settings{
+			TargetBranch: "master",
+		},
+	}
+	repo := &RepositoryConfig{
+		Settings: Settings{
+			TargetBranch: "develop",
+			Squash:       boolPtr(true),
+		},
+	}
+
+	got := EffectiveSettings("gitlab.com", profile, "/home/lab/config.yml", repo, "/repo/.lab.yml")
+	want := map[string]*EffectiveSetting{
+		"target_branch":  {Key: "target_branch", Value: "develop", Source: "/repo/.lab.yml"},
+		"squash":         {Key: "squash", Value: "true", Source: "/repo/.lab.yml"},
+		"default_group":  {Key: "default_group", Value: "group", Source: "profile gitlab.com (/home/lab/config.yml)"},
+		"default_labels": {Key: "default_labels", Value: "", Source: "not set"},
+	}
+	for _, s := range got {
+		w, ok := want[s.Key]
+		if !ok {
+			continue
+		}
+		if diff := cmp.Diff(s, w); diff != "" {
+			t.Errorf("Invalid result: (-got +want)\n%s", diff)
+		}
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
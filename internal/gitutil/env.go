@@ -0,0 +1,67 @@
+package gitutil
+
+import "os"
+
+// These are the environment variables that override the target project.
+const (
+	EnvToken       = "LAB_TOKEN"
+	EnvGitLabToken = "GITLAB_TOKEN"
+	EnvHost        = "LAB_HOST"
+	EnvProject     = "LAB_PROJECT"
+	EnvProfile     = "LAB_PROFILE"
+)
+
+// These are predefined by GitLab CI when lab runs inside a job.
+const (
+	EnvGitLabCI      = "GITLAB_CI"
+	EnvCIJobToken    = "CI_JOB_TOKEN"
+	EnvCIServerURL   = "CI_SERVER_URL"
+	EnvCIProjectPath = "CI_PROJECT_PATH"
+)
+
+// For environment variable test
+var getenv = os.Getenv
+
+type envTarget struct {
+	Token         string
+	Host          string
+	Project       string
+	Profile       string
+	CI            bool
+	CIJobToken    string
+	CIServerURL   string
+	CIProjectPath string
+}
+
+func newEnvTarget() *envTarget {
+	token := getenv(EnvToken)
+	if token == "" {
+		token = getenv(EnvGitLabToken)
+	}
+	return &envTarget{
+		Token:         token,
+		Host:          getenv(EnvHost),
+		Project:       getenv(EnvProject),
+		Profile:       getenv(EnvProfile),
+		CI:            getenv(EnvGitLabCI) == "true",
+		CIJobToken:    getenv(EnvCIJobToken),
+		CIServerURL:   getenv(EnvCIServerURL),
+		CIProjectPath: getenv(EnvCIProjectPath),
+	}
+}
+
+// hasTarget reports whether the environment points at a host without the local repository.
+func (e *envTarget) hasTarget() bool {
+	if e.Host != "" || e.Profile != "" {
+		return true
+	}
+	return e.CI && e.CIServerURL != ""
+}
+
+// hasCredential reports whether the environment provides a token.
+func (e *envTarget) hasCredential() bool {
+	if e.Token != "" {
+		return true
+	}
+	return e.CI && e.CIJobToken != ""
+}
@@ -2,9 +2,11 @@ package gitutil
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 
 	"github.com/lighttiger2505/lab/git"
+	"github.com/lighttiger2505/lab/internal/api"
 	"github.com/lighttiger2505/lab/internal/config"
 	"github.com/lighttiger2505/lab/internal/ui"
 )
@@ -17,18 +19,54 @@ type RemoteCollecter struct {
 	UI        ui.UI
 	GitClient git.Client
 	Cfg       *config.Config
+	// Remote is the git remote given by the "--remote" option
+	Remote string
 }
 
 type GitLabProjectInfo struct {
+	Scheme        string
 	Domain        string
 	Project       string
 	Token         string
+	TokenType     string
 	CurrentBranch string
-	Profile       *config.Profile
+	// Remote is the name of the git remote of the project. It is empty when the project is not given by the local repository.
+	Remote  string
+	Profile *config.Profile
+	// tokenDomain is the domain of the profile whose token is not resolved yet.
+	// The token is resolved at last, so that token_command and the keyring are not used when the environment overrides the token.
+	tokenDomain string
+}
+
+// setToken overrides the token of the profile.
+func (r *GitLabProjectInfo) setToken(token, tokenType string) {
+	r.Token = token
+	r.TokenType = tokenType
+	r.tokenDomain = ""
+}
+
+// hasToken reports whether the token is given, or will be resolved from the profile.
+func (r *GitLabProjectInfo) hasToken() bool {
+	if r.tokenDomain != "" {
+		return r.Profile.HasToken()
+	}
+	return r.Token != ""
 }
 
 func (r *GitLabProjectInfo) BaseUrl() string {
-	return "https://" + r.Domain
+	scheme := r.Scheme
+	if scheme == "" {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Domain
+}
+
+// setProject overrides the project, and forgets the git remote when it belongs to another project.
+func (r *GitLabProjectInfo) setProject(project string) {
+	if r.Project != project {
+		r.Remote = ""
+	}
+	r.Project = project
 }
 
 func (r *GitLabProjectInfo) ApiUrl() string {
@@ -59,35 +97,59 @@ func (r *GitLabProjectInfo) Subpage(subpage string) string {
 	return strings.Join([]string{r.RepositoryUrl(), subpage}, "/")
 }
 
-func NewRemoteCollecter(ui ui.UI, cfg *config.Config, gitClient git.Client) Collecter {
+func NewRemoteCollecter(ui ui.UI, cfg *config.Config, gitClient git.Client, remote string) Collecter {
 	return &RemoteCollecter{
 		UI:        ui,
 		Cfg:       cfg,
 		GitClient: gitClient,
+		Remote:    remote,
 	}
 }
 
+// CollectTarget resolves the GitLab project to be processed.
+// Later sources take precedence over earlier ones:
+//  1. default_profile in the config file
+//  2. GitLab remote of the local repository
+//  3. GitLab CI predefined variables (CI_SERVER_URL, CI_PROJECT_PATH, CI_JOB_TOKEN)
+//  4. LAB_PROFILE
+//  5. LAB_HOST, LAB_PROJECT, LAB_TOKEN or GITLAB_TOKEN
+//  6. --profile and --project options
+//
+// The settings in .lab.yml at the git root are merged over the profile.
 func (c *RemoteCollecter) CollectTarget(project, profile string) (*GitLabProjectInfo, error) {
 	pInfo := &GitLabProjectInfo{}
+	env := newEnvTarget()
 	var err error
 
 	isGitDir, err := git.IsGitDirReverseTop()
 	if err != nil {
 		return nil, err
 	}
+
+	pInfo, err = c.collectTargetByDefaultConfig(pInfo)
+	if err != nil {
+		return nil, err
+	}
 	if isGitDir {
-		pInfo = c.collectTargetByDefaultConfig(pInfo)
-		pInfo, err = c.collectTargetByLocalRepository(pInfo)
+		pInfo, err = c.collectTargetByLocalRepository(pInfo, env)
 		if err != nil {
 			return nil, err
 		}
-		pInfo, err = c.collectTargetByArgs(pInfo, project, profile)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		pInfo = c.collectTargetByDefaultConfig(pInfo)
-		pInfo, err = c.collectTargetByArgs(pInfo, project, profile)
+	}
+	pInfo, err = c.collectTargetByEnv(pInfo, env)
+	if err != nil {
+		return nil, err
+	}
+	pInfo, err = c.collectTargetByArgs(pInfo, project, profile)
+	if err != nil {
+		return nil, err
+	}
+	if err := resolveToken(pInfo); err != nil {
+		return nil, err
+	}
+
+	if isGitDir {
+		pInfo, err = c.collectTargetByRepositoryConfig(pInfo)
 		if err != nil {
 			return nil, err
 		}
@@ -96,39 +158,83 @@ func (c *RemoteCollecter) CollectTarget(project, profile string) (*GitLabProject
 	return pInfo, nil
 }
 
-func (c *RemoteCollecter) collectTargetByDefaultConfig(pInfo *GitLabProjectInfo) *GitLabProjectInfo {
-	if c.Cfg.DefalutProfile == "" {
-		return pInfo
+// collectTargetByRepositoryConfig merges the .lab.yml at the git root over the profile.
+func (c *RemoteCollecter) collectTargetByRepositoryConfig(pInfo *GitLabProjectInfo) (*GitLabProjectInfo, error) {
+	root, err := git.Root()
+	if err != nil {
+		return nil, err
 	}
-	profile := c.Cfg.GetDefaultProfile()
-	pInfo.Profile = profile
-	pInfo.Domain = c.Cfg.DefalutProfile
-	pInfo.Token = profile.Token
+	repo, err := config.LoadRepositoryConfig(root)
+	if err != nil {
+		return nil, err
+	}
+	if pInfo.Profile == nil {
+		pInfo.Profile = &config.Profile{}
+	}
+	pInfo.Profile = repo.Merge(pInfo.Profile)
+	return pInfo, nil
+}
 
-	if profile.DefaultProject == "" {
-		return pInfo
+func (c *RemoteCollecter) collectTargetByDefaultConfig(pInfo *GitLabProjectInfo) (*GitLabProjectInfo, error) {
+	if c.Cfg.DefaultProfile == "" {
+		return pInfo, nil
+	}
+	if err := c.applyProfile(pInfo, c.Cfg.DefaultProfile); err != nil {
+		return nil, err
 	}
-	pInfo.Project = profile.DefaultProject
 
-	return pInfo
+	if pInfo.Profile.DefaultProject == "" {
+		return pInfo, nil
+	}
+	pInfo.Project = pInfo.Profile.DefaultProject
+
+	return pInfo, nil
 }
 
-func (c *RemoteCollecter) collectTargetByLocalRepository(pInfo *GitLabProjectInfo) (*GitLabProjectInfo, error) {
+func (c *RemoteCollecter) collectTargetByLocalRepository(pInfo *GitLabProjectInfo, env *envTarget) (*GitLabProjectInfo, error) {
 	gitRemotes, err := c.GitClient.RemoteInfos()
 	if err != nil {
+		if env.hasTarget() {
+			return pInfo, nil
+		}
 		return nil, err
 	}
 
-	gitlabRemotes := filterHasGitlabDomain(gitRemotes, c.Cfg)
-	if len(gitlabRemotes) == 0 {
+	targetRepo, err := c.selectRemote(gitRemotes)
+	if err != nil {
+		return nil, err
+	}
+	if targetRepo == nil {
+		if env.hasTarget() {
+			return pInfo, nil
+		}
 		return nil, fmt.Errorf("Not found gitlab remote repository")
 	}
-	gitlabRemotes = excludeDuplicateDomain(gitlabRemotes)
-	targetRepo := gitlabRemotes[0]
 
 	var domain, token string
 
 	domain = targetRepo.Domain
+
+	// The environment provides the credentials or overrides the domain, so never prompt or write the config.
+	// The token is resolved later for the domain of the target.
+	if env.hasCredential() || env.hasTarget() {
+		pInfo, err = c.applyDomain(pInfo, domain, "")
+		if err != nil {
+			return nil, err
+		}
+		pInfo.Project = targetRepo.RepositoryFullName()
+		pInfo.Remote = targetRepo.Remote
+		currentBranch, err := c.GitClient.CurrentRemoteBranch()
+		if err != nil {
+			return nil, err
+		}
+		pInfo.CurrentBranch = currentBranch
+		return pInfo, nil
+	}
+
+	if !c.Cfg.HasDomain(domain) && !ui.Interactive(c.UI) {
+		return nil, c.missingTokenError(domain)
+	}
 	if !c.Cfg.HasDomain(domain) {
 		c.UI.Message(fmt.Sprintf("Not found this domain [%s].", domain))
 		c.Cfg.SetProfile(domain, config.Profile{})
@@ -138,7 +244,13 @@ func (c *RemoteCollecter) collectTargetByLocalRepository(pInfo *GitLabProjectInf
 		c.UI.Message("Saved profile.")
 	}
 
-	token = c.Cfg.GetToken(domain)
+	token, err = c.Cfg.GetToken(domain)
+	if err != nil {
+		return nil, err
+	}
+	if token == "" && !ui.Interactive(c.UI) {
+		return nil, c.missingTokenError(domain)
+	}
 	if token == "" {
 		c.UI.Message(fmt.Sprintf("Not found private token in the domain [%s].", domain))
 		token, err = c.UI.Ask("Please enter GitLab private token:")
@@ -146,7 +258,9 @@ func (c *RemoteCollecter) collectTargetByLocalRepository(pInfo *GitLabProjectInf
 			return nil, fmt.Errorf("cannot read private token, %s", err)
 		}
 
-		c.Cfg.SetToken(domain, token)
+		if err := c.Cfg.SetToken(domain, token); err != nil {
+			return nil, err
+		}
 		if err := c.Cfg.Save(); err != nil {
 			return nil, err
 		}
@@ -159,9 +273,11 @@ func (c *RemoteCollecter) collectTargetByLocalRepository(pInfo *GitLabProjectInf
 	}
 
 	pInfo.Profile = profile
+	pInfo.Scheme = ""
 	pInfo.Domain = domain
-	pInfo.Token = token
+	pInfo.setToken(token, TokenType(profile))
 	pInfo.Project = targetRepo.RepositoryFullName()
+	pInfo.Remote = targetRepo.Remote
 
 	currentBranch, err := c.GitClient.CurrentRemoteBranch()
 	if err != nil {
@@ -172,19 +288,145 @@ func (c *RemoteCollecter) collectTargetByLocalRepository(pInfo *GitLabProjectInf
 	return pInfo, nil
 }
 
+func (c *RemoteCollecter) missingTokenError(domain string) error {
+	return fmt.Errorf(
+		"Not found private token in the domain [%s]. Set \"token\" of the profile [%s] in %s, or %s",
+		domain,
+		domain,
+		c.Cfg.Path(),
+		EnvToken,
+	)
+}
+
+func (c *RemoteCollecter) collectTargetByEnv(pInfo *GitLabProjectInfo, env *envTarget) (*GitLabProjectInfo, error) {
+	var err error
+
+	if env.CI {
+		if env.CIServerURL != "" {
+			pInfo, err = c.applyDomain(pInfo, env.CIServerURL, "")
+			if err != nil {
+				return nil, err
+			}
+		}
+		if env.CIProjectPath != "" {
+			pInfo.setProject(env.CIProjectPath)
+		}
+		if !pInfo.hasToken() && env.CIJobToken != "" {
+			pInfo.setToken(env.CIJobToken, api.JobToken)
+		}
+	}
+
+	if env.Profile != "" {
+		if err := c.applyProfile(pInfo, env.Profile); err != nil {
+			return nil, fmt.Errorf("%s, specified by %s", err, EnvProfile)
+		}
+	}
+
+	if env.Host != "" {
+		pInfo, err = c.applyDomain(pInfo, env.Host, "https")
+		if err != nil {
+			return nil, err
+		}
+	}
+	if env.Project != "" {
+		pInfo.setProject(env.Project)
+	}
+	if env.Token != "" {
+		pInfo.setToken(env.Token, "")
+	}
+
+	return pInfo, nil
+}
+
+// applyDomain switches the target to the host given as a domain or a URL.
+// The token of the profile for the domain is used when it exists in the config.
+func (c *RemoteCollecter) applyDomain(pInfo *GitLabProjectInfo, host, defaultScheme string) (*GitLabProjectInfo, error) {
+	scheme, domain, err := parseHost(host)
+	if err != nil {
+		return nil, err
+	}
+	if scheme == "" {
+		scheme = defaultScheme
+	}
+	if scheme == "https" {
+		scheme = ""
+	}
+
+	if pInfo.Domain == domain && pInfo.Profile != nil {
+		pInfo.Scheme = scheme
+		return pInfo, nil
+	}
+
+	if c.Cfg.HasDomain(domain) {
+		if err := c.applyProfile(pInfo, domain); err != nil {
+			return nil, err
+		}
+	} else {
+		pInfo.Domain = domain
+		pInfo.setToken("", "")
+		pInfo.Profile = &config.Profile{}
+	}
+	pInfo.Scheme = scheme
+	return pInfo, nil
+}
+
+// applyProfile switches the target to the profile of the domain. The token is resolved later by resolveToken.
+func (c *RemoteCollecter) applyProfile(pInfo *GitLabProjectInfo, domain string) error {
+	p, err := c.Cfg.GetProfile(domain)
+	if err != nil {
+		return err
+	}
+	pInfo.Profile = p
+	pInfo.Scheme = ""
+	pInfo.Domain = domain
+	pInfo.Token = ""
+	pInfo.TokenType = TokenType(p)
+	pInfo.tokenDomain = domain
+	return nil
+}
+
+// resolveToken resolves the token of the profile, unless the token is overridden.
+func resolveToken(pInfo *GitLabProjectInfo) error {
+	if pInfo.tokenDomain == "" {
+		return nil
+	}
+	token, err := pInfo.Profile.ResolveToken(pInfo.tokenDomain)
+	if err != nil {
+		return err
+	}
+	pInfo.Token = token
+	pInfo.tokenDomain = ""
+	return nil
+}
+
+// TokenType returns the type of the token of the profile.
+func TokenType(p *config.Profile) string {
+	if p.IsOAuth() {
+		return api.OAuthToken
+	}
+	return api.PrivateToken
+}
+
+func parseHost(host string) (string, string, error) {
+	if !strings.Contains(host, "://") {
+		return "", strings.TrimSuffix(host, "/"), nil
+	}
+	u, err := url.Parse(host)
+	if err != nil || u.Host == "" {
+		return "", "", fmt.Errorf("Invalid host, %s", host)
+	}
+	return u.Scheme, u.Host, nil
+}
+
 func (c *RemoteCollecter) collectTargetByArgs(pInfo *GitLabProjectInfo, project, profile string) (*GitLabProjectInfo, error) {
 	if profile != "" {
-		p, err := c.Cfg.GetProfile(profile)
-		if err != nil {
+		if err := c.applyProfile(pInfo, profile); err != nil {
 			return nil, err
 		}
-		pInfo.Profile = p
-		pInfo.Domain = profile
-		pInfo.Token = p.Token
 	}
 
 	if project != "" {
-		pInfo.Project = project
+		pInfo.setProject(project)
 	}
 
 	return pInfo, nil
@@ -202,25 +444,53 @@ func filterHasGitlabDomain(remoteInfos []*git.RemoteInfo, cfg *config.Config) []
 	return gitlabRemotes
 }
 
-func excludeDuplicateDomain(remotes []*git.RemoteInfo) []*git.RemoteInfo {
-	domainRemotesMap := map[string][]*git.RemoteInfo{}
-	for _, remote := range remotes {
-		domain := remote.Domain
-		domainRemotesMap[domain] = append(domainRemotesMap[domain], remote)
+// selectRemote chooses the git remote of the target project in the following order.
+// It returns nil when no GitLab remote is found.
+//  1. the remote given by the "--remote" option
+//  2. preferred_remotes in the config file
+//  3. the remote tracked by the current branch
+//  4. origin
+//  5. the first GitLab remote
+func (c *RemoteCollecter) selectRemote(remotes []*git.RemoteInfo) (*git.RemoteInfo, error) {
+	if c.Remote != "" {
+		remote := findRemote(remotes, c.Remote)
+		if remote == nil {
+			return nil, fmt.Errorf("Not found git remote [%s]", c.Remote)
+		}
+		return remote, nil
 	}
 
-	processedRemotes := []*git.RemoteInfo{}
-	for _, v := range domainRemotesMap {
-		var tmpRemote = v[0]
-		for _, remote := range v {
-			if remote.Remote == "origin" {
-				tmpRemote = remote
-				break
-			}
+	gitlabRemotes := filterHasGitlabDomain(remotes, c.Cfg)
+	if len(gitlabRemotes) == 0 {
+		return nil, nil
+	}
+
+	for _, name := range c.Cfg.PreferredRemotes {
+		if remote := findRemote(gitlabRemotes, name); remote != nil {
+			return remote, nil
+		}
+	}
+
+	// A branch without upstream, or a detached HEAD, falls back to the defaults
+	if upstream, err := c.GitClient.UpstreamRemote(); err == nil && upstream != "" {
+		if remote := findRemote(gitlabRemotes, upstream); remote != nil {
+			return remote, nil
+		}
+	}
+
+	if remote := findRemote(gitlabRemotes, "origin"); remote != nil {
+		return remote, nil
+	}
+	return gitlabRemotes[0], nil
+}
+
+func findRemote(remotes []*git.RemoteInfo, name string) *git.RemoteInfo {
+	for _, remote := range remotes {
+		if remote.Remote == name {
+			return remote
 		}
-		processedRemotes = append(processedRemotes, tmpRemote)
 	}
-	return processedRemotes
+	return nil
 }
 
 type MockCollecter struct{}
@@ -1,11 +1,15 @@
 package gitutil
 
 import (
+	"os"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/lighttiger2505/lab/git"
+	"github.com/lighttiger2505/lab/internal/api"
 	"github.com/lighttiger2505/lab/internal/config"
+	"github.com/lighttiger2505/lab/internal/ui"
 )
 
 func Test_filterHasGitlabDomain(t *testing.T) {
@@ -94,3 +98,287 @@ func Test_filterHasGitlabDomain(t *testing.T) {
 		})
 	}
 }
+
+func TestRemoteCollecter_collectTargetByEnv(t *testing.T) {
+	cfg := &config.Config{
+		Profiles: map[string]config.Profile{
+			"gitlab.com":         {Token: "configtoken"},
+			"gitlab.example.com": {Token: "profiletoken"},
+			// The failing command must not run, as LAB_TOKEN overrides it
+			"command.example.com": {TokenCommand: "false"},
+		},
+	}
+	tests := []struct {
+		name  string
+		env   map[string]string
+		pInfo *GitLabProjectInfo
+		want  *GitLabProjectInfo
+	}{
+		{
+			name: "no environment",
+			env:  map[string]string{},
+			pInfo: &GitLabProjectInfo{
+				Domain:  "gitlab.com",
+				Project: "group/project",
+				Token:   "configtoken",
+				Profile: &config.Profile{Token: "configtoken"},
+			},
+			want: &GitLabProjectInfo{
+				Domain:  "gitlab.com",
+				Project: "group/project",
+				Token:   "configtoken",
+				Profile: &config.Profile{Token: "configtoken"},
+			},
+		},
+		{
+			name: "lab variables",
+			env: map[string]string{
+				EnvHost:    "http://localhost:8080",
+				EnvProject: "env/project",
+				EnvToken:   "envtoken",
+			},
+			pInfo: &GitLabProjectInfo{
+				Domain:  "gitlab.com",
+				Project: "group/project",
+				Token:   "configtoken",
+				Profile: &config.Profile{Token: "configtoken"},
+			},
+			want: &GitLabProjectInfo{
+				Scheme:  "http",
+				Domain:  "localhost:8080",
+				Project: "env/project",
+				Token:   "envtoken",
+				Profile: &config.Profile{},
+			},
+		},
+		{
+			name: "gitlab token and profile",
+			env: map[string]string{
+				EnvProfile:     "gitlab.example.com",
+				EnvGitLabToken: "gitlabtoken",
+			},
+			pInfo: &GitLabProjectInfo{},
+			want: &GitLabProjectInfo{
+				Domain:  "gitlab.example.com",
+				Token:   "gitlabtoken",
+				Profile: &config.Profile{Token: "profiletoken"},
+			},
+		},
+		{
+			name: "lab token without running token command",
+			env: map[string]string{
+				EnvProfile: "command.example.com",
+				EnvToken:   "envtoken",
+			},
+			pInfo: &GitLabProjectInfo{},
+			want: &GitLabProjectInfo{
+				Domain:  "command.example.com",
+				Token:   "envtoken",
+				Profile: &config.Profile{TokenCommand: "false"},
+			},
+		},
+		{
+			name: "gitlab ci",
+			env: map[string]string{
+				EnvGitLabCI:      "true",
+				EnvCIServerURL:   "https://gitlab.ci.com",
+				EnvCIProjectPath: "ci/project",
+				EnvCIJobToken:    "jobtoken",
+			},
+			pInfo: &GitLabProjectInfo{},
+			want: &GitLabProjectInfo{
+				Domain:    "gitlab.ci.com",
+				Project:   "ci/project",
+				Token:     "jobtoken",
+				TokenType: api.JobToken,
+				Profile:   &config.Profile{},
+			},
+		},
+		{
+			name: "gitlab ci with configured token",
+			env: map[string]string{
+				EnvGitLabCI:      "true",
+				EnvCIServerURL:   "https://gitlab.com",
+				EnvCIProjectPath: "ci/project",
+				EnvCIJobToken:    "jobtoken",
+			},
+			pInfo: &GitLabProjectInfo{},
+			want: &GitLabProjectInfo{
+				Domain:  "gitlab.com",
+				Project: "ci/project",
+				Token:   "configtoken",
+				Profile: &config.Profile{Token: "configtoken"},
+			},
+		},
+	}
+	defer func() { getenv = os.Getenv }()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv = func(key string) string { return tt.env[key] }
+			c := &RemoteCollecter{Cfg: cfg}
+			got, err := c.collectTargetByEnv(tt.pInfo, newEnvTarget())
+			if err != nil {
+				t.Fatalf("collectTargetByEnv() error = %v", err)
+			}
+			if err := resolveToken(got); err != nil {
+				t.Fatalf("resolveToken() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("collectTargetByEnv() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemoteCollecter_collectTargetByLocalRepositoryNoInput(t *testing.T) {
+	gitClient := &git.MockClient{
+		MockRemoteInfos: func() ([]*git.RemoteInfo, error) {
+			return []*git.RemoteInfo{
+				{Remote: "origin", Domain: "gitlab.com", Group: "group", Repository: "project"},
+			}, nil
+		},
+		MockCurrentRemoteBranch: func() (string, error) {
+			return "master", nil
+		},
+	}
+	tests := []struct {
+		name string
+		cfg  *config.Config
+	}{
+		{
+			name: "not found domain",
+			cfg:  &config.Config{Profiles: map[string]config.Profile{}},
+		},
+		{
+			name: "not found token",
+			cfg:  &config.Config{Profiles: map[string]config.Profile{"gitlab.com": {}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := len(tt.cfg.Profiles)
+			c := &RemoteCollecter{
+				UI:        &ui.BasicUi{NoInput: true},
+				Cfg:       tt.cfg,
+				GitClient: gitClient,
+			}
+			_, err := c.collectTargetByLocalRepository(&GitLabProjectInfo{}, &envTarget{})
+			if err == nil {
+				t.Fatal("collectTargetByLocalRepository() want error in non-interactive mode")
+			}
+			if !strings.Contains(err.Error(), "gitlab.com") {
+				t.Errorf("error does not name the domain, %v", err)
+			}
+			if got := len(tt.cfg.Profiles); got != want {
+				t.Errorf("profiles changed in non-interactive mode, got %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestRemoteCollecter_collectTargetByLocalRepositoryEnvHost(t *testing.T) {
+	gitClient := &git.MockClient{
+		MockRemoteInfos: func() ([]*git.RemoteInfo, error) {
+			return []*git.RemoteInfo{
+				{Remote: "origin", Domain: "gitlab.com", Group: "group", Repository: "project"},
+			}, nil
+		},
+		MockCurrentRemoteBranch: func() (string, error) {
+			return "master", nil
+		},
+	}
+	cfg := &config.Config{
+		Profiles: map[string]config.Profile{
+			"gitlab.example.com": {Token: "profiletoken"},
+		},
+	}
+	mockUI := ui.NewMockUi()
+	c := &RemoteCollecter{
+		UI:        mockUI,
+		Cfg:       cfg,
+		GitClient: gitClient,
+	}
+
+	defer func() { getenv = os.Getenv }()
+	getenv = func(key string) string {
+		return map[string]string{EnvHost: "gitlab.example.com"}[key]
+	}
+	env := newEnvTarget()
+	got, err := c.collectTargetByLocalRepository(&GitLabProjectInfo{}, env)
+	if err != nil {
+		t.Fatalf("collectTargetByLocalRepository() error = %v", err)
+	}
+	if got.Project != "group/project" || got.Remote != "origin" || got.CurrentBranch != "master" {
+		t.Errorf("collectTargetByLocalRepository() = %+v", got)
+	}
+	if got, err = c.collectTargetByEnv(got, env); err != nil {
+		t.Fatalf("collectTargetByEnv() error = %v", err)
+	}
+	if err := resolveToken(got); err != nil {
+		t.Fatalf("resolveToken() error = %v", err)
+	}
+
+	if got.Domain != "gitlab.example.com" || got.Token != "profiletoken" {
+		t.Errorf("target = %+v, want the token of gitlab.example.com", got)
+	}
+	if cfg.HasDomain("gitlab.com") {
+		t.Error("profile of the git remote is added")
+	}
+	if out := mockUI.Writer.String(); out != "" {
+		t.Errorf("prompted, %q", out)
+	}
+}
+
+func TestRemoteCollecter_selectRemote(t *testing.T) {
+	remotes := []*git.RemoteInfo{
+		{Remote: "github", Domain: "github.com"},
+		{Remote: "fork", Domain: "gitlab.com"},
+		{Remote: "origin", Domain: "gitlab.com"},
+		{Remote: "upstream", Domain: "gitlab.com"},
+	}
+	tests := []struct {
+		name      string
+		remote    string
+		preferred []string
+		upstream  string
+		remotes   []*git.RemoteInfo
+		want      string
+		wantErr   bool
+	}{
+		{name: "origin", remotes: remotes, want: "origin"},
+		{name: "option", remote: "upstream", upstream: "fork", remotes: remotes, want: "upstream"},
+		{name: "option not found", remote: "unknown", remotes: remotes, wantErr: true},
+		{name: "preferred", preferred: []string{"unknown", "upstream"}, upstream: "fork", remotes: remotes, want: "upstream"},
+		{name: "tracking", upstream: "fork", remotes: remotes, want: "fork"},
+		{name: "tracking not gitlab", upstream: "github", remotes: remotes, want: "origin"},
+		{name: "first", remotes: remotes[:2], want: "fork"},
+		{name: "not found", remotes: remotes[:1], want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &RemoteCollecter{
+				Cfg: &config.Config{
+					Profiles:         map[string]config.Profile{},
+					PreferredRemotes: tt.preferred,
+				},
+				GitClient: &git.MockClient{
+					MockUpstreamRemote: func() (string, error) {
+						return tt.upstream, nil
+					},
+				},
+				Remote: tt.remote,
+			}
+			got, err := c.selectRemote(tt.remotes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("selectRemote() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			gotName := ""
+			if got != nil {
+				gotName = got.Remote
+			}
+			if gotName != tt.want {
+				t.Errorf("selectRemote() = %q, want %q", gotName, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/lighttiger2505/lab/internal/browse"
+)
+
+// DefaultScopes is requested when no scope is given.
+var DefaultScopes = []string{"api"}
+
+// Client runs the OAuth 2.0 authorization code flow with PKCE against GitLab.
+type Client struct {
+	// BaseURL is the URL of GitLab, e.g. https://gitlab.com
+	BaseURL  string
+	ClientID string
+	Scopes   []string
+	// Port of the loopback redirect URI. A free port is used when 0.
+	Port       int
+	HTTPClient *http.Client
+	Timeout    time.Duration
+}
+
+type Token struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	Scope        string `json:"scope"`
+}
+
+type errorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Login opens the consent page and waits for the authorization code on the loopback redirect URI.
+func (c *Client) Login(opener browse.URLOpener) (*Token, error) {
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", c.Port))
+	if err != nil {
+		return nil, fmt.Errorf("cannot listen redirect port, %s", err)
+	}
+	defer listener.Close()
+	redirectURI := fmt.Sprintf("http://%s/callback", listener.Addr().String())
+
+	verifier, err := randomString(32)
+	if err != nil {
+		return nil, err
+	}
+	state, err := randomString(16)
+	if err != nil {
+		return nil, err
+	}
+
+	codeCh := make(chan string, 1)
+	errCh := make(chan error, 1)
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/callback" {
+				http.NotFound(w, r)
+				return
+			}
+			q := r.URL.Query()
+			if e := q.Get("error"); e != "" {
+				fmt.Fprintln(w, "Authorization failed. You can close this window.")
+				errCh <- fmt.Errorf("authorization failed, %s %s", e, q.Get("error_description"))
+				return
+			}
+			if q.Get("state") != state {
+				http.Error(w, "invalid state", http.StatusBadRequest)
+				errCh <- fmt.Errorf("authorization failed, invalid state")
+				return
+			}
+			fmt.Fprintln(w, "Authorization succeeded. You can close this window.")
+			codeCh <- q.Get("code")
+		}),
+	}
+	go server.Serve(listener)
+	defer server.Close()
+
+	authURL := c.authorizeURL(redirectURI, state, challenge(verifier))
+	if err := opener.Open(authURL); err != nil {
+		return nil, fmt.Errorf("cannot open browser, %s. Please open %s", err, authURL)
+	}
+
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = 5 * time.Minute
+	}
+	select {
+	case code := <-codeCh:
+		return c.exchange(url.Values{
+			"grant_type":    {"authorization_code"},
+			"client_id":     {c.ClientID},
+			"code":          {code},
+			"redirect_uri":  {redirectURI},
+			"code_verifier": {verifier},
+		})
+	case err := <-errCh:
+		return nil, err
+	case <-time.After(timeout):
+		return nil, fmt.Errorf("timed out waiting for authorization")
+	}
+}
+
+// Refresh exchanges the refresh token for a new access token.
+func (c *Client) Refresh(refreshToken string) (*Token, error) {
+	return c.exchange(url.Values{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {c.ClientID},
+		"refresh_token": {refreshToken},
+	})
+}
+
+func (c *Client) authorizeURL(redirectURI, state, codeChallenge string) string {
+	scopes := c.Scopes
+	if len(scopes) == 0 {
+		scopes = DefaultScopes
+	}
+	q := url.Values{
+		"client_id":             {c.ClientID},
+		"redirect_uri":          {redirectURI},
+		"response_type":         {"code"},
+		"state":                 {state},
+		"scope":                 {strings.Join(scopes, " ")},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+	return strings.TrimSuffix(c.BaseURL, "/") + "/oauth/authorize?" + q.Encode()
+}
+
+func (c *Client) exchange(form url.Values) (*Token, error) {
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	req, err := http.NewRequest("POST", strings.TrimSuffix(c.BaseURL, "/")+"/oauth/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Failed request token. %s", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		e := &errorResponse{}
+		json.NewDecoder(res.Body).Decode(e)
+		return nil, fmt.Errorf("Failed request token. %s %s %s", res.Status, e.Error, e.ErrorDescription)
+	}
+
+	token := &Token{}
+	if err := json.NewDecoder(res.Body).Decode(token); err != nil {
+		return nil, fmt.Errorf("Failed decode token. %s", err)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("Failed request token. empty access token")
+	}
+	return token, nil
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("cannot generate random string, %s", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
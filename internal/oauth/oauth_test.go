@@ -0,0 +1,119 @@
+package oauth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/lighttiger2505/lab/internal/browse"
+	"github.com/lighttiger2505/lab/internal/config"
+)
+
+// newStubServer is a stub of the GitLab authorization server.
+func newStubServer(t *testing.T) *httptest.Server {
+	var codeChallenge string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/authorize", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("client_id") != "clientid" || q.Get("code_challenge_method") != "S256" || q.Get("scope") != "api" {
+			t.Errorf("invalid authorize request, %v", q)
+		}
+		codeChallenge = q.Get("code_challenge")
+		redirect := q.Get("redirect_uri") + "?" + url.Values{
+			"code":  {"authcode"},
+			"state": {q.Get("state")},
+		}.Encode()
+		http.Redirect(w, r, redirect, http.StatusFound)
+	})
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		var res *Token
+		switch r.PostForm.Get("grant_type") {
+		case "authorization_code":
+			if r.PostForm.Get("code") != "authcode" || challenge(r.PostForm.Get("code_verifier")) != codeChallenge {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(&errorResponse{Error: "invalid_grant"})
+				return
+			}
+			res = &Token{AccessToken: "accesstoken", RefreshToken: "refreshtoken"}
+		case "refresh_token":
+			if r.PostForm.Get("refresh_token") != "refreshtoken" {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(&errorResponse{Error: "invalid_grant"})
+				return
+			}
+			res = &Token{AccessToken: "newaccesstoken", RefreshToken: "newrefreshtoken"}
+		}
+		json.NewEncoder(w).Encode(res)
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestClient_Login(t *testing.T) {
+	server := newStubServer(t)
+	defer server.Close()
+
+	opener := &browse.MockOpener{
+		MockOpen: func(u string) error {
+			go func() {
+				res, err := http.Get(u)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				res.Body.Close()
+			}()
+			return nil
+		},
+	}
+	client := &Client{
+		BaseURL:  server.URL,
+		ClientID: "clientid",
+		Timeout:  5 * time.Second,
+	}
+
+	token, err := client.Login(opener)
+	if err != nil {
+		t.Fatalf("Client.Login() error = %v", err)
+	}
+	if token.AccessToken != "accesstoken" || token.RefreshToken != "refreshtoken" {
+		t.Errorf("Client.Login() = %+v", token)
+	}
+}
+
+func TestClient_Refresh(t *testing.T) {
+	server := newStubServer(t)
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:  server.URL,
+		ClientID: "clientid",
+	}
+
+	token, err := client.Refresh("refreshtoken")
+	if err != nil {
+		t.Fatalf("Client.Refresh() error = %v", err)
+	}
+	if token.AccessToken != "newaccesstoken" || token.RefreshToken != "newrefreshtoken" {
+		t.Errorf("Client.Refresh() = %+v", token)
+	}
+
+	if _, err := client.Refresh("invalid"); err == nil {
+		t.Error("Client.Refresh() want error for invalid refresh token")
+	}
+}
+
+func TestRefresher_RefreshReadOnly(t *testing.T) {
+	config.SetReadOnly(true)
+	defer config.SetReadOnly(false)
+
+	r := NewRefresher(config.NewConfig())
+	if _, err := r.Refresh("https://gitlab.com", "token"); err == nil {
+		t.Error("Refresh() want error in read-only mode")
+	}
+}
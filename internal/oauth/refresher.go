@@ -0,0 +1,76 @@
+package oauth
+
+import (
+	"fmt"
+
+	"github.com/lighttiger2505/lab/internal/config"
+)
+
+// Refresher refreshes the OAuth access token of a profile and saves the new tokens to the config.
+type Refresher struct {
+	Config *config.Config
+}
+
+func NewRefresher(cfg *config.Config) *Refresher {
+	return &Refresher{Config: cfg}
+}
+
+// Refresh exchanges the refresh token of the profile for a new access token at the GitLab of baseURL.
+func (r *Refresher) Refresh(baseURL, token string) (string, error) {
+	// GitLab revokes the old refresh token, so the new tokens must be saved
+	if config.ReadOnly() {
+		return "", fmt.Errorf("cannot refresh the OAuth token without saving the config. Please run lab without --no-input")
+	}
+
+	domain, profile, err := r.findProfile(token)
+	if err != nil {
+		return "", err
+	}
+
+	refreshToken, err := r.Config.GetRefreshToken(domain)
+	if err != nil {
+		return "", err
+	}
+	if refreshToken == "" {
+		return "", fmt.Errorf("not found refresh token in the domain [%s]. Please run \"lab auth login --web\"", domain)
+	}
+
+	client := &Client{
+		BaseURL:  baseURL,
+		ClientID: profile.OAuthClientID,
+	}
+	newToken, err := client.Refresh(refreshToken)
+	if err != nil {
+		return "", err
+	}
+
+	if err := r.Config.SetToken(domain, newToken.AccessToken); err != nil {
+		return "", err
+	}
+	if newToken.RefreshToken != "" {
+		if err := r.Config.SetRefreshToken(domain, newToken.RefreshToken); err != nil {
+			return "", err
+		}
+	}
+	if err := r.Config.Save(); err != nil {
+		return "", err
+	}
+	return newToken.AccessToken, nil
+}
+
+func (r *Refresher) findProfile(token string) (string, *config.Profile, error) {
+	for domain := range r.Config.Profiles {
+		profile, err := r.Config.GetProfile(domain)
+		if err != nil || !profile.IsOAuth() {
+			continue
+		}
+		t, err := profile.ResolveToken(domain)
+		if err != nil {
+			continue
+		}
+		if t == token {
+			return domain, profile, nil
+		}
+	}
+	return "", nil, fmt.Errorf("not found OAuth profile of the token")
+}
@@ -8,6 +8,7 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"sync"
 )
 
@@ -23,6 +24,8 @@ type BasicUi struct {
 	Reader      io.Reader
 	Writer      io.Writer
 	ErrorWriter io.Writer
+	// NoInput makes every Ask fail instead of waiting for the user.
+	NoInput     bool
 	l           sync.Mutex
 	interrupted bool
 	scanner     *bufio.Scanner
@@ -44,6 +47,10 @@ func (rw *BasicUi) Ask(query string) (string, error) {
 		return "", errors.New("interrupted")
 	}
 
+	if rw.NoInput {
+		return "", fmt.Errorf("cannot ask %q, input is disabled in non-interactive mode", query)
+	}
+
 	if rw.scanner == nil {
 		rw.scanner = bufio.NewScanner(rw.Reader)
 	}
@@ -86,6 +93,35 @@ func (rw *BasicUi) Ask(query string) (string, error) {
 	}
 }
 
+func (rw *BasicUi) Interactive() bool {
+	return !rw.NoInput
+}
+
+// Interactive reports whether the UI can ask the user for input.
+func Interactive(u UI) bool {
+	i, ok := u.(interface{ Interactive() bool })
+	if !ok {
+		return true
+	}
+	return i.Interactive()
+}
+
+// Confirm asks a yes or no question. It returns false without asking in non-interactive mode.
+func Confirm(u UI, query string) (bool, error) {
+	if !Interactive(u) {
+		return false, nil
+	}
+	answer, err := u.Ask(query + " [y/N]")
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
+
 func (rw *BasicUi) Say(message string) {
 	rw.l.Lock()
 	defer rw.l.Unlock()
@@ -8,18 +8,23 @@ import (
 	"os"
 
 	"github.com/lighttiger2505/lab/commands"
+	"github.com/lighttiger2505/lab/commands/auth"
+	"github.com/lighttiger2505/lab/commands/board"
 	configcmd "github.com/lighttiger2505/lab/commands/config"
 	"github.com/lighttiger2505/lab/commands/issue"
 	"github.com/lighttiger2505/lab/commands/milestone"
 	"github.com/lighttiger2505/lab/commands/mr"
 	"github.com/lighttiger2505/lab/commands/pipeline"
 	"github.com/lighttiger2505/lab/commands/runner"
+	"github.com/lighttiger2505/lab/commands/timesheet"
+	"github.com/lighttiger2505/lab/commands/todo"
 	"github.com/lighttiger2505/lab/git"
 	"github.com/lighttiger2505/lab/internal/api"
 	"github.com/lighttiger2505/lab/internal/browse"
 	"github.com/lighttiger2505/lab/internal/clipboard"
 	"github.com/lighttiger2505/lab/internal/config"
 	"github.com/lighttiger2505/lab/internal/gitutil"
+	"github.com/lighttiger2505/lab/internal/oauth"
 	"github.com/lighttiger2505/lab/internal/ui"
 	"github.com/mitchellh/cli"
 )
@@ -40,9 +45,19 @@ func main() {
 }
 
 func realMain(writer io.Writer, ver, rev string) int {
+	gOpt, args, err := extractGlobalOptions(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return ExitCodeError
+	}
+	if gOpt.Config != "" {
+		config.SetPath(gOpt.Config)
+	}
+
 	c := cli.NewCLI("lab", fmt.Sprintf("ver: %s rev: %s", ver, rev))
-	c.Args = os.Args[1:]
+	c.Args = args
 	c.HelpWriter = writer
+	c.HelpFunc = globalHelpFunc(cli.BasicHelpFunc("lab"))
 
 	// Determine where logs should go in general (requested by the user)
 	logWriter, err := logOutput()
@@ -57,11 +72,15 @@ func realMain(writer io.Writer, ver, rev string) int {
 	log.SetOutput(ioutil.Discard)
 
 	ui := ui.NewBasicUi()
+	ui.NoInput = gOpt.NoInput || !isTerminal(os.Stdin)
+	config.SetReadOnly(gOpt.NoInput)
 	cfg, err := config.GetConfig()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "cannot load config, %s", err)
+		fmt.Fprintf(os.Stderr, "cannot load config, %s\n", err)
+		return ExitCodeError
 	}
-	remoteCollecter := gitutil.NewRemoteCollecter(ui, cfg, git.NewGitClient())
+	remoteCollecter := gitutil.NewRemoteCollecter(ui, cfg, git.NewGitClient(), gOpt.Remote)
+	refresher := oauth.NewRefresher(cfg)
 
 	c.Commands = map[string]cli.CommandFactory{
 		"browse": func() (cli.Command, error) {
@@ -71,7 +90,7 @@ func realMain(writer io.Writer, ver, rev string) int {
 				GitClient:       &git.GitClient{},
 				Clipboard:       &clipboard.ClipboardRW{},
 				Opener:          &browse.Browser{},
-				ClientFactory:   &api.GitlabClientFactory{},
+				ClientFactory:   &api.GitlabClientFactory{Refresher: refresher},
 			}, nil
 		},
 		"issue": func() (cli.Command, error) {
@@ -79,27 +98,30 @@ func realMain(writer io.Writer, ver, rev string) int {
 				UI:              ui,
 				RemoteCollecter: remoteCollecter,
 				MethodFactory:   &issue.IssueMethodFactory{},
+				TokenRefresher:  refresher,
 			}, nil
 		},
 		"merge-request": func() (cli.Command, error) {
 			return &mr.MergeRequestCommand{
 				UI:              ui,
 				RemoteCollecter: remoteCollecter,
-				ClientFactory:   &api.GitlabClientFactory{},
+				GitClient:       git.NewGitClient(),
+				ClientFactory:   &api.GitlabClientFactory{Refresher: refresher},
 			}, nil
 		},
 		"mr": func() (cli.Command, error) {
 			return &mr.MergeRequestCommand{
 				UI:              ui,
 				RemoteCollecter: remoteCollecter,
-				ClientFactory:   &api.GitlabClientFactory{},
+				GitClient:       git.NewGitClient(),
+				ClientFactory:   &api.GitlabClientFactory{Refresher: refresher},
 			}, nil
 		},
 		"project": func() (cli.Command, error) {
 			return &commands.ProjectCommand{
 				UI:              ui,
 				RemoteCollecter: remoteCollecter,
-				ClientFactory:   &api.GitlabClientFactory{},
+				ClientFactory:   &api.GitlabClientFactory{Refresher: refresher},
 			}, nil
 		},
 		"pipeline": func() (cli.Command, error) {
@@ -107,68 +129,152 @@ func realMain(writer io.Writer, ver, rev string) int {
 				UI:              ui,
 				RemoteCollecter: remoteCollecter,
 				MethodFactory:   &pipeline.PipelineMethodFacotry{},
+				TokenRefresher:  refresher,
 			}, nil
 		},
 		"job": func() (cli.Command, error) {
 			return &commands.JobCommand{
 				UI:              ui,
 				RemoteCollecter: remoteCollecter,
-				ClientFactory:   &api.GitlabClientFactory{},
+				ClientFactory:   &api.GitlabClientFactory{Refresher: refresher},
 			}, nil
 		},
 		"lint": func() (cli.Command, error) {
 			return &commands.LintCommand{
 				UI:              ui,
 				RemoteCollecter: remoteCollecter,
-				ClientFactory:   &api.GitlabClientFactory{},
+				ClientFactory:   &api.GitlabClientFactory{Refresher: refresher},
 			}, nil
 		},
 		"user": func() (cli.Command, error) {
 			return &commands.UserCommand{
 				UI:              ui,
 				RemoteCollecter: remoteCollecter,
-				ClientFactory:   &api.GitlabClientFactory{},
+				ClientFactory:   &api.GitlabClientFactory{Refresher: refresher},
 			}, nil
 		},
 		"project-variable": func() (cli.Command, error) {
 			return &commands.ProjectVariableCommand{
 				UI:              ui,
 				RemoteCollecter: remoteCollecter,
-				ClientFactory:   &api.GitlabClientFactory{},
+				ClientFactory:   &api.GitlabClientFactory{Refresher: refresher},
 			}, nil
 		},
 		"issue-template": func() (cli.Command, error) {
 			return &commands.IssueTemplateCommand{
 				UI:              ui,
 				RemoteCollecter: remoteCollecter,
-				ClientFactory:   &api.GitlabClientFactory{},
+				ClientFactory:   &api.GitlabClientFactory{Refresher: refresher},
 			}, nil
 		},
 		"merge-request-template": func() (cli.Command, error) {
 			return &commands.MergeRequestTemplateCommand{
 				UI:              ui,
 				RemoteCollecter: remoteCollecter,
-				ClientFactory:   &api.GitlabClientFactory{},
+				ClientFactory:   &api.GitlabClientFactory{Refresher: refresher},
 			}, nil
 		},
 		"runner": func() (cli.Command, error) {
 			return &runner.RunnerCommand{
 				UI:              ui,
 				RemoteCollecter: remoteCollecter,
-				ClientFactory:   &api.GitlabClientFactory{},
+				ClientFactory:   &api.GitlabClientFactory{Refresher: refresher},
 			}, nil
 		},
 		"config": func() (cli.Command, error) {
 			return &configcmd.ConfigCommand{
+				UI:              ui,
+				Config:          cfg,
+				RemoteCollecter: remoteCollecter,
+			}, nil
+		},
+		"config get": func() (cli.Command, error) {
+			return &configcmd.GetCommand{
+				UI:     ui,
+				Config: cfg,
+			}, nil
+		},
+		"config set": func() (cli.Command, error) {
+			return &configcmd.SetCommand{
+				UI:     ui,
+				Config: cfg,
+			}, nil
+		},
+		"config profiles": func() (cli.Command, error) {
+			return &configcmd.ProfilesCommand{
+				UI:     ui,
+				Config: cfg,
+			}, nil
+		},
+		"config use": func() (cli.Command, error) {
+			return &configcmd.UseCommand{
+				UI:     ui,
+				Config: cfg,
+			}, nil
+		},
+		"config add-profile": func() (cli.Command, error) {
+			return &configcmd.AddProfileCommand{
 				UI:     ui,
 				Config: cfg,
 			}, nil
 		},
+		"config validate": func() (cli.Command, error) {
+			return &configcmd.ValidateCommand{
+				UI:            ui,
+				Config:        cfg,
+				ClientFactory: &api.GitlabClientFactory{Refresher: refresher},
+			}, nil
+		},
+		"auth": func() (cli.Command, error) {
+			return &auth.AuthCommand{}, nil
+		},
+		"auth login": func() (cli.Command, error) {
+			return &auth.LoginCommand{
+				UI:            ui,
+				Config:        cfg,
+				ClientFactory: &api.GitlabClientFactory{Refresher: refresher},
+				Opener:        &browse.Browser{},
+			}, nil
+		},
+		"auth status": func() (cli.Command, error) {
+			return &auth.StatusCommand{
+				UI:            ui,
+				Config:        cfg,
+				ClientFactory: &api.GitlabClientFactory{Refresher: refresher},
+			}, nil
+		},
+		"auth logout": func() (cli.Command, error) {
+			return &auth.LogoutCommand{
+				UI:     ui,
+				Config: cfg,
+			}, nil
+		},
+		"board": func() (cli.Command, error) {
+			return &board.BoardCommand{
+				UI:              ui,
+				RemoteCollecter: remoteCollecter,
+				ClientFactory:   &api.GitlabClientFactory{Refresher: refresher},
+			}, nil
+		},
 		"milestone": func() (cli.Command, error) {
 			return &milestone.MilestoneCommand{
 				UI:              ui,
 				RemoteCollecter: remoteCollecter,
-				ClientFactory:   &api.GitlabClientFactory{},
+				ClientFactory:   &api.GitlabClientFactory{Refresher: refresher},
+			}, nil
+		},
+		"timesheet": func() (cli.Command, error) {
+			return &timesheet.TimesheetCommand{
+				UI:              ui,
+				RemoteCollecter: remoteCollecter,
+				ClientFactory:   &api.GitlabClientFactory{Refresher: refresher},
+			}, nil
+		},
+		"todo": func() (cli.Command, error) {
+			return &todo.TodoCommand{
+				UI:              ui,
+				RemoteCollecter: remoteCollecter,
+				ClientFactory:   &api.GitlabClientFactory{Refresher: refresher},
 			}, nil
 		},
 	}
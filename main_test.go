@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"reflect"
 	"testing"
 )
 
@@ -24,3 +25,63 @@ func TestRun_Version(t *testing.T) {
 		t.Fatalf("bad stdout \nwant %q \ngot  %q", outWant, outGot)
 	}
 }
+
+func TestExtractGlobalOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		want     *globalOption
+		wantArgs []string
+		wantErr  bool
+	}{
+		{
+			name:     "no global option",
+			args:     []string{"issue", "-n", "10"},
+			want:     &globalOption{},
+			wantArgs: []string{"issue", "-n", "10"},
+		},
+		{
+			name:     "no input",
+			args:     []string{"--no-input", "issue", "1"},
+			want:     &globalOption{NoInput: true},
+			wantArgs: []string{"issue", "1"},
+		},
+		{
+			name:     "after double dash",
+			args:     []string{"issue", "--", "--no-input"},
+			want:     &globalOption{},
+			wantArgs: []string{"issue", "--", "--no-input"},
+		},
+		{
+			name:     "config",
+			args:     []string{"--config", "/tmp/lab.yml", "issue", "--config=/tmp/other.yml"},
+			want:     &globalOption{Config: "/tmp/other.yml"},
+			wantArgs: []string{"issue"},
+		},
+		{
+			name:     "remote",
+			args:     []string{"mr", "--remote", "upstream", "-n", "10"},
+			want:     &globalOption{Remote: "upstream"},
+			wantArgs: []string{"mr", "-n", "10"},
+		},
+		{
+			name:    "config without path",
+			args:    []string{"issue", "--config"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotArgs, err := extractGlobalOptions(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("extractGlobalOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("extractGlobalOptions() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("extractGlobalOptions() gotArgs = %v, want %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}